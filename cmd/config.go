@@ -23,92 +23,134 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/starlight/ocelot/pkg/config"
 )
 
 // configCmd represents the config command
 var configCmd = &cobra.Command{
 	Use:   "config key [value]",
 	Short: "Configure Ocelot behavior",
-	Long:  `Configure Ocelot behavior.`,
-	Args:  cobra.RangeArgs(1, 2),
+	Long: `Configure Ocelot behavior.
+
+With --profile, values are read from and written to that profile,
+falling back to the top level for keys the profile does not set.`,
+	Args: cobra.RangeArgs(1, 2),
+	// only warn about invalid values, config is how they get fixed
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := config.Validate(); err != nil {
+			fmt.Fprintln(os.Stderr, "Warning:", err)
+		}
+	},
 	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		// key
 		if len(args) == 0 {
-			res := []string{}
-			for _, key := range viper.AllKeys() {
-				if strings.Contains(key, toComplete) {
-					res = append(res, key)
-				}
-			}
-			return res, cobra.ShellCompDirectiveNoFileComp
+			return completeKeys(toComplete), cobra.ShellCompDirectiveNoFileComp
 		}
 		// value
-		value := viper.Get(args[0])
-		switch value.(type) {
-		default:
+		key, err := config.Lookup(args[0])
+		if err != nil || key.Type != config.Bool {
 			return nil, cobra.ShellCompDirectiveNoFileComp
-		case bool:
-			// complete boolean values using `not`
-			notValue := cast.ToString(!cast.ToBool(value))
-			return []string{notValue}, cobra.ShellCompDirectiveNoFileComp
 		}
+		// complete boolean values using `not`
+		notValue := cast.ToString(!config.GetBool(key.Name))
+		return []string{notValue}, cobra.ShellCompDirectiveNoFileComp
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		key := args[0]
 		if len(args) == 1 {
-			value := viper.Get(key)
-			if value == nil {
-				// not a key with a value set
-				return fmt.Errorf("Unknown config key \"%s\"", key)
+			value, err := config.Get(args[0])
+			if err != nil {
+				return err
 			}
 			fmt.Println(value)
-		} else if len(args) == 2 {
-			// TODO refactor out typed Set function
-			value := args[1]
-			// don't change the type from the default
-			switch viper.Get(key).(type) {
-			default:
-				// trying to set a group value
-				return fmt.Errorf("Invalid config key \"%s\"", key)
-			case nil:
-				// not a key with a value set
-				return fmt.Errorf("Unknown config key \"%s\"", key)
-			case string:
-				viper.Set(key, value)
-				break
-			case bool:
-				boolValue, err := cast.ToBoolE(value)
-				if err != nil {
-					return fmt.Errorf("Invalid boolean value \"%s\" for key \"%s\"", value, key)
-				}
-				viper.Set(key, boolValue)
-				break
-			case int64:
-				intValue, err := cast.ToInt64E(value)
-				if err != nil {
-					return fmt.Errorf("Invalid integer value \"%s\" for key \"%s\"", value, key)
-				}
-				viper.Set(key, intValue)
-				break
-			case float64:
-				floatValue, err := cast.ToFloat64E(value)
-				if err != nil {
-					return fmt.Errorf("Invalid float value \"%s\" for key \"%s\"", value, key)
-				}
-				viper.Set(key, floatValue)
-				break
+			return nil
+		}
+		return config.Set(args[0], args[1])
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List config keys with their values",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for _, key := range config.Keys() {
+			value, err := config.Get(key.Name)
+			if err != nil {
+				value = fmt.Sprintf("<%v>", err)
 			}
-			viper.WriteConfig()
+			fmt.Fprintf(w, "%s\t%v\t%s\t%s\n", key.Name, value, key.Type, key.Description)
+		}
+		return w.Flush()
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset key",
+	Short: "Remove a key from the config file, restoring its default",
+	Args:  cobra.ExactArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeKeys(toComplete), cobra.ShellCompDirectiveNoFileComp
 		}
-		return nil
+		return nil, cobra.ShellCompDirectiveNoFileComp
 	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return config.Unset(args[0])
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the config file in $VISUAL or $EDITOR",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := viper.ConfigFileUsed()
+		if path == "" {
+			return fmt.Errorf("no config file")
+		}
+		editor := os.Getenv("VISUAL")
+		if editor == "" {
+			editor = os.Getenv("EDITOR")
+		}
+		if editor == "" {
+			editor = "vi"
+		}
+		// editor may carry its own args, e.g. "code --wait"
+		fields := strings.Fields(editor)
+		edit := exec.Command(fields[0], append(fields[1:], path)...)
+		edit.Stdin, edit.Stdout, edit.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := edit.Run(); err != nil {
+			return err
+		}
+		if err := viper.ReadInConfig(); err != nil {
+			return err
+		}
+		return config.Validate()
+	},
+}
+
+func completeKeys(toComplete string) []string {
+	res := []string{}
+	for _, key := range config.Keys() {
+		if strings.Contains(key.Name, toComplete) {
+			res = append(res, key.Name)
+		}
+	}
+	return res
 }
 
 func init() {
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configEditCmd)
 	rootCmd.AddCommand(configCmd)
 }
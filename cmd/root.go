@@ -23,18 +23,21 @@ package cmd
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/starlight/ocelot/pkg/base"
 	"github.com/starlight/ocelot/pkg/builtin"
+	"github.com/starlight/ocelot/pkg/config"
 	"github.com/starlight/ocelot/pkg/ocelot"
 )
 
 var (
 	version string
 	cfgFile string
+	profile string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -44,16 +47,19 @@ var rootCmd = &cobra.Command{
 	Long:    `An open command-line trading system written in Go.`,
 	Args:    cobra.ArbitraryArgs,
 	Version: version,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		cobra.CheckErr(config.Validate())
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) == 0 {
-			err := ocelot.Repl("$ ")
+			err := ocelot.Repl(shutdown, "$ ", Quit)
+			builtin.RunShutdownHooks()
 			cobra.CheckErr(err)
 		} else {
-			env, err := builtin.BuiltinEnv()
-			cobra.CheckErr(err)
-			val, err := base.EvalStr(strings.Join(args, " "), env)
+			builtins, err := builtin.BuiltinEnv()
 			cobra.CheckErr(err)
-			ocelot.Print(val)
+			env := base.NewEnvContext(shutdown, builtins)
+			finish(base.EvalStr(strings.Join(args, " "), env))
 		}
 	},
 }
@@ -70,6 +76,7 @@ func Execute() {
 func init() {
 	cobra.OnInitialize(initConfig)
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default ~/.ocelot.toml)")
+	rootCmd.PersistentFlags().StringVarP(&profile, "profile", "p", "", "config profile, e.g. paper or live (default $OCLT_PROFILE)")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -82,13 +89,13 @@ func initConfig() {
 		// Search config in home directory with filename ".ocelot.toml".
 		viper.AddConfigPath(home)
 		viper.SetConfigName(".ocelot")
-		// create default config
-		viper.SafeWriteConfig()
+		// create empty config, defaults stay in the code
+		createFile(filepath.Join(home, ".ocelot.toml"))
 	} else {
 		// Use specified file
 		viper.SetConfigFile(cfgFile)
 		// create if not exists
-		viper.SafeWriteConfigAs(cfgFile)
+		createFile(cfgFile)
 	}
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err != nil {
@@ -104,10 +111,19 @@ func initConfig() {
 	viper.AutomaticEnv()
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
 	viper.SetEnvPrefix("OCLT")
-	// cast types on `Get` to match default values
-	viper.SetTypeByDefaultValue(true)
+	// settings under [profile.<name>]
+	if profile == "" {
+		profile = os.Getenv("OCLT_PROFILE")
+	}
+	config.Profile = strings.ToLower(profile)
+	// module search path, also from OCLT_PATH
+	builtin.SearchPath = filepath.SplitList(config.GetString("path"))
 }
 
-func Quit() {
-	os.Exit(0)
+// create an empty file unless one exists
+func createFile(path string) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err == nil {
+		file.Close()
+	}
 }
@@ -25,7 +25,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/starlight/ocelot/pkg/base"
 	"github.com/starlight/ocelot/pkg/builtin"
-	"github.com/starlight/ocelot/pkg/ocelot"
 )
 
 // runCmd represents the run command
@@ -39,11 +38,10 @@ Cobra is a CLI library for Go that empowers applications.
 This application is a tool to generate the needed files
 to quickly create a Cobra application.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		env, err := builtin.BuiltinEnv()
+		builtins, err := builtin.BuiltinEnv()
 		cobra.CheckErr(err)
-		any, err := base.EvalFile(args[0], env)
-		cobra.CheckErr(err)
-		ocelot.Print(any)
+		env := base.NewEnvContext(shutdown, builtins)
+		finish(base.EvalFile(args[0], env))
 	},
 }
 
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/starlight/ocelot/pkg/builtin"
+	"github.com/starlight/ocelot/pkg/core"
+	"github.com/starlight/ocelot/pkg/ocelot"
+)
+
+// cancelled on shutdown, stopping running evaluations
+var shutdown, cancelShutdown = context.WithCancel(context.Background())
+
+// exit status once shut down by a signal, 128+signal by convention
+var shutdownCode int
+
+// cancel running evaluations on sig, which then quit
+func Shutdown(sig os.Signal) {
+	shutdownCode = ExitCode(sig)
+	cancelShutdown()
+}
+
+// exit status for termination by sig: 130 for SIGINT, 143 for SIGTERM
+func ExitCode(sig os.Signal) int {
+	if num, ok := sig.(syscall.Signal); ok {
+		return 128 + int(num)
+	}
+	return 1
+}
+
+// run shutdown hooks and exit
+func Quit() {
+	builtin.RunShutdownHooks()
+	os.Exit(shutdownCode)
+}
+
+// end of a script: run shutdown hooks, then report
+func finish(val core.Any, err error) {
+	if shutdown.Err() != nil {
+		// cancelled, not a script error
+		Quit()
+	}
+	builtin.RunShutdownHooks()
+	if err == nil {
+		err = core.PrintError(val)
+	}
+	cobra.CheckErr(err)
+	ocelot.Print(val)
+}
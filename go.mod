@@ -9,6 +9,7 @@ require (
 	github.com/mattn/go-runewidth v0.0.13 // indirect
 	github.com/mattn/go-tty v0.0.4 // indirect
 	github.com/mna/pigeon v1.1.0 // indirect
+	github.com/rivo/uniseg v0.2.0
 	github.com/shopspring/decimal v1.3.1
 	github.com/spf13/afero v1.8.2 // indirect
 	github.com/spf13/cast v1.4.1
@@ -1,51 +1,145 @@
 package parser
 
-import "github.com/starlight/ocelot/pkg/core"
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/starlight/ocelot/pkg/core"
+)
 
 // cast to []interface{}
-func slice(v interface{}) []interface{} {
+func slice(v interface{}) ([]interface{}, error) {
 	if v == nil {
-		return nil
+		return nil, nil
 	}
-	return v.([]interface{})
+	res, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected parse result %#v", v)
+	}
+	return res, nil
+}
+
+// cast to core.Any
+func toAny(v interface{}) (core.Any, error) {
+	res, ok := v.(core.Any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected parse result %#v", v)
+	}
+	return res, nil
 }
 
 // build []core.Any from first, rest=[[_, next], ...]
-func join(first, rest interface{}, index int) []core.Any {
+func join(first, rest interface{}, index int) ([]core.Any, error) {
 	if first == nil {
-		return []core.Any{}
+		return []core.Any{}, nil
+	}
+	more, err := slice(rest)
+	if err != nil {
+		return nil, err
 	}
-	more := slice(rest)
 	result := make([]core.Any, len(more)+1)
-	result[0] = first.(core.Any)
+	if result[0], err = toAny(first); err != nil {
+		return nil, err
+	}
 	for i, group := range more {
-		next := slice(group)[index]
-		result[i+1] = next.(core.Any)
+		next, err := slice(group)
+		if err != nil {
+			return nil, err
+		}
+		if result[i+1], err = toAny(next[index]); err != nil {
+			return nil, err
+		}
 	}
-	return result
+	return result, nil
 }
 
-func merge(first, rest interface{}, keyIndex int, valueIndex int) map[core.String]core.Any {
-	pair := slice(first)
+func merge(first, rest interface{}, keyIndex int, valueIndex int) (core.Hash, error) {
+	pair, err := slice(first)
 	if pair == nil {
-		return map[core.String]core.Any{}
+		return core.Hash{}, err
+	}
+	more, err := slice(rest)
+	if err != nil {
+		return nil, err
 	}
-	more := slice(rest)
-	result := make(map[core.String]core.Any, len(more)+1)
+	result := make(core.Hash, len(more)+1)
 	// assign helper
-	assign := func(keyval []interface{}, keyN int, valN int) {
-		key := keyval[keyN].(core.String)
-		result[key] = keyval[valN].(core.Any)
+	assign := func(keyval []interface{}, keyN int, valN int) error {
+		key, ok := keyval[keyN].(core.String)
+		if !ok {
+			return fmt.Errorf("unexpected hash key %#v", keyval[keyN])
+		}
+		val, err := toAny(keyval[valN])
+		if err != nil {
+			return err
+		}
+		result[key] = val
+		return nil
 	}
 	// assign pairs
-	assign(pair, keyIndex, valueIndex)
+	if err := assign(pair, keyIndex, valueIndex); err != nil {
+		return nil, err
+	}
 	for _, group := range more {
-		pair := slice(group)
-		assign(pair, keyIndex+1, valueIndex+1)
+		pair, err := slice(group)
+		if err != nil {
+			return nil, err
+		}
+		if err := assign(pair, keyIndex+1, valueIndex+1); err != nil {
+			return nil, err
+		}
 	}
-	return result
+	return result, nil
 }
 
-func pos(p position) *core.Position {
-	return &core.Position{Line: p.line, Col: p.col, Offset: p.offset}
+// position of current match, within source from the global store
+func pos(c *current) *core.Position {
+	src, _ := c.globalStore["source"].(*core.Source)
+	return &core.Position{
+		Line:   c.pos.line,
+		Col:    c.pos.col,
+		Offset: c.pos.offset,
+		End:    c.pos.offset + len(c.text),
+		Source: src,
+	}
+}
+
+// parse named source text with positions
+func ParseSource(name string, text []byte) (core.Any, error) {
+	src := &core.Source{Name: name, Text: text}
+	res, err := Parse(name, text, GlobalStore("source", src))
+	if err != nil {
+		return nil, err
+	}
+	return toAny(res)
+}
+
+// parse file with positions
+func ParseSourceFile(filename string) (core.Any, error) {
+	text, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSource(filename, text)
+}
+
+// node at the position of the current match, symbols are positioned already
+func node(v interface{}, c *current) (core.Any, error) {
+	val, err := toAny(v)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := val.(core.Symbol); ok {
+		return val, nil
+	}
+	return core.Node{Val: val, Pos: pos(c)}, nil
+}
+
+// build (name form) for reader macros
+func wrap(name string, form interface{}, c *current) (core.Expr, error) {
+	any, err := toAny(form)
+	if err != nil {
+		return nil, err
+	}
+	return core.Expr{core.NewSymbol(name, pos(c)), any}, nil
 }
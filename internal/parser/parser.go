@@ -49,48 +49,48 @@ var g = &grammar{
 		},
 		{
 			name: "Seq",
-			pos:  position{line: 13, col: 1, offset: 195},
+			pos:  position{line: 13, col: 1, offset: 199},
 			expr: &actionExpr{
-				pos: position{line: 13, col: 8, offset: 204},
+				pos: position{line: 13, col: 8, offset: 208},
 				run: (*parser).callonSeq1,
 				expr: &seqExpr{
-					pos: position{line: 13, col: 8, offset: 204},
+					pos: position{line: 13, col: 8, offset: 208},
 					exprs: []interface{}{
 						&zeroOrMoreExpr{
-							pos: position{line: 13, col: 8, offset: 204},
+							pos: position{line: 13, col: 8, offset: 208},
 							expr: &ruleRefExpr{
-								pos:  position{line: 13, col: 8, offset: 204},
+								pos:  position{line: 13, col: 8, offset: 208},
 								name: "_",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 13, col: 11, offset: 207},
+							pos:   position{line: 13, col: 11, offset: 211},
 							label: "first",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 13, col: 17, offset: 213},
+								pos: position{line: 13, col: 17, offset: 217},
 								expr: &ruleRefExpr{
-									pos:  position{line: 13, col: 17, offset: 213},
+									pos:  position{line: 13, col: 17, offset: 217},
 									name: "Any",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 13, col: 22, offset: 218},
+							pos:   position{line: 13, col: 22, offset: 222},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 13, col: 27, offset: 223},
+								pos: position{line: 13, col: 27, offset: 227},
 								expr: &seqExpr{
-									pos: position{line: 13, col: 28, offset: 224},
+									pos: position{line: 13, col: 28, offset: 228},
 									exprs: []interface{}{
 										&oneOrMoreExpr{
-											pos: position{line: 13, col: 28, offset: 224},
+											pos: position{line: 13, col: 28, offset: 228},
 											expr: &ruleRefExpr{
-												pos:  position{line: 13, col: 28, offset: 224},
+												pos:  position{line: 13, col: 28, offset: 228},
 												name: "_",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 13, col: 31, offset: 227},
+											pos:  position{line: 13, col: 31, offset: 231},
 											name: "Any",
 										},
 									},
@@ -98,9 +98,9 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 13, col: 37, offset: 233},
+							pos: position{line: 13, col: 37, offset: 237},
 							expr: &ruleRefExpr{
-								pos:  position{line: 13, col: 37, offset: 233},
+								pos:  position{line: 13, col: 37, offset: 237},
 								name: "_",
 							},
 						},
@@ -110,45 +110,61 @@ var g = &grammar{
 		},
 		{
 			name: "Any",
-			pos:  position{line: 18, col: 1, offset: 297},
-			expr: &choiceExpr{
-				pos: position{line: 18, col: 9, offset: 307},
-				alternatives: []interface{}{
-					&ruleRefExpr{
-						pos:  position{line: 18, col: 9, offset: 307},
-						name: "Atom",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 18, col: 16, offset: 314},
-						name: "Symbol",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 18, col: 25, offset: 323},
-						name: "Expr",
+			pos:  position{line: 18, col: 1, offset: 313},
+			expr: &actionExpr{
+				pos: position{line: 18, col: 9, offset: 323},
+				run: (*parser).callonAny1,
+				expr: &labeledExpr{
+					pos:   position{line: 18, col: 9, offset: 323},
+					label: "any",
+					expr: &choiceExpr{
+						pos: position{line: 18, col: 14, offset: 328},
+						alternatives: []interface{}{
+							&ruleRefExpr{
+								pos:  position{line: 18, col: 14, offset: 328},
+								name: "Atom",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 18, col: 21, offset: 335},
+								name: "Keyword",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 18, col: 31, offset: 345},
+								name: "Symbol",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 18, col: 40, offset: 354},
+								name: "Expr",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 18, col: 47, offset: 361},
+								name: "Quasi",
+							},
+						},
 					},
 				},
 			},
 		},
 		{
 			name: "Atom",
-			pos:  position{line: 21, col: 1, offset: 363},
+			pos:  position{line: 23, col: 1, offset: 429},
 			expr: &choiceExpr{
-				pos: position{line: 21, col: 9, offset: 373},
+				pos: position{line: 23, col: 9, offset: 439},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 21, col: 9, offset: 373},
+						pos:  position{line: 23, col: 9, offset: 439},
 						name: "Number",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 21, col: 18, offset: 382},
+						pos:  position{line: 23, col: 18, offset: 448},
 						name: "String",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 21, col: 27, offset: 391},
+						pos:  position{line: 23, col: 27, offset: 457},
 						name: "Vector",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 21, col: 36, offset: 400},
+						pos:  position{line: 23, col: 36, offset: 466},
 						name: "Hash",
 					},
 				},
@@ -156,32 +172,32 @@ var g = &grammar{
 		},
 		{
 			name: "Expr",
-			pos:  position{line: 24, col: 1, offset: 422},
+			pos:  position{line: 26, col: 1, offset: 488},
 			expr: &choiceExpr{
-				pos: position{line: 24, col: 9, offset: 432},
+				pos: position{line: 26, col: 9, offset: 498},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 24, col: 9, offset: 432},
+						pos: position{line: 26, col: 9, offset: 498},
 						run: (*parser).callonExpr2,
 						expr: &seqExpr{
-							pos: position{line: 24, col: 9, offset: 432},
+							pos: position{line: 26, col: 9, offset: 498},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 24, col: 9, offset: 432},
+									pos:        position{line: 26, col: 9, offset: 498},
 									val:        "(",
 									ignoreCase: false,
 									want:       "\"(\"",
 								},
 								&labeledExpr{
-									pos:   position{line: 24, col: 13, offset: 436},
+									pos:   position{line: 26, col: 13, offset: 502},
 									label: "seq",
 									expr: &ruleRefExpr{
-										pos:  position{line: 24, col: 17, offset: 440},
+										pos:  position{line: 26, col: 17, offset: 506},
 										name: "Seq",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 24, col: 21, offset: 444},
+									pos:        position{line: 26, col: 21, offset: 510},
 									val:        ")",
 									ignoreCase: false,
 									want:       "\")\"",
@@ -190,25 +206,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 26, col: 5, offset: 496},
+						pos: position{line: 28, col: 5, offset: 562},
 						run: (*parser).callonExpr8,
 						expr: &seqExpr{
-							pos: position{line: 26, col: 5, offset: 496},
+							pos: position{line: 28, col: 5, offset: 562},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 26, col: 5, offset: 496},
+									pos:        position{line: 28, col: 5, offset: 562},
 									val:        "(",
 									ignoreCase: false,
 									want:       "\"(\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 26, col: 9, offset: 500},
+									pos:  position{line: 28, col: 9, offset: 566},
 									name: "Seq",
 								},
 								&notExpr{
-									pos: position{line: 26, col: 13, offset: 504},
+									pos: position{line: 28, col: 13, offset: 570},
 									expr: &litMatcher{
-										pos:        position{line: 26, col: 14, offset: 505},
+										pos:        position{line: 28, col: 14, offset: 571},
 										val:        ")",
 										ignoreCase: false,
 										want:       "\")\"",
@@ -220,34 +236,112 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "Quasi",
+			pos:  position{line: 33, col: 1, offset: 679},
+			expr: &choiceExpr{
+				pos: position{line: 33, col: 10, offset: 690},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 33, col: 10, offset: 690},
+						run: (*parser).callonQuasi2,
+						expr: &seqExpr{
+							pos: position{line: 33, col: 10, offset: 690},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 33, col: 10, offset: 690},
+									val:        "`",
+									ignoreCase: false,
+									want:       "\"`\"",
+								},
+								&labeledExpr{
+									pos:   position{line: 33, col: 14, offset: 694},
+									label: "form",
+									expr: &ruleRefExpr{
+										pos:  position{line: 33, col: 19, offset: 699},
+										name: "Any",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 35, col: 5, offset: 746},
+						run: (*parser).callonQuasi7,
+						expr: &seqExpr{
+							pos: position{line: 35, col: 5, offset: 746},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 35, col: 5, offset: 746},
+									val:        "~@",
+									ignoreCase: false,
+									want:       "\"~@\"",
+								},
+								&labeledExpr{
+									pos:   position{line: 35, col: 10, offset: 751},
+									label: "form",
+									expr: &ruleRefExpr{
+										pos:  position{line: 35, col: 15, offset: 756},
+										name: "Any",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 37, col: 5, offset: 807},
+						run: (*parser).callonQuasi12,
+						expr: &seqExpr{
+							pos: position{line: 37, col: 5, offset: 807},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 37, col: 5, offset: 807},
+									val:        "~",
+									ignoreCase: false,
+									want:       "\"~\"",
+								},
+								&labeledExpr{
+									pos:   position{line: 37, col: 9, offset: 811},
+									label: "form",
+									expr: &ruleRefExpr{
+										pos:  position{line: 37, col: 14, offset: 816},
+										name: "Any",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Vector",
-			pos:  position{line: 31, col: 1, offset: 583},
+			pos:  position{line: 42, col: 1, offset: 877},
 			expr: &choiceExpr{
-				pos: position{line: 31, col: 11, offset: 595},
+				pos: position{line: 42, col: 11, offset: 889},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 31, col: 11, offset: 595},
+						pos: position{line: 42, col: 11, offset: 889},
 						run: (*parser).callonVector2,
 						expr: &seqExpr{
-							pos: position{line: 31, col: 11, offset: 595},
+							pos: position{line: 42, col: 11, offset: 889},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 31, col: 11, offset: 595},
+									pos:        position{line: 42, col: 11, offset: 889},
 									val:        "[",
 									ignoreCase: false,
 									want:       "\"[\"",
 								},
 								&labeledExpr{
-									pos:   position{line: 31, col: 15, offset: 599},
+									pos:   position{line: 42, col: 15, offset: 893},
 									label: "seq",
 									expr: &ruleRefExpr{
-										pos:  position{line: 31, col: 19, offset: 603},
+										pos:  position{line: 42, col: 19, offset: 897},
 										name: "Seq",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 31, col: 23, offset: 607},
+									pos:        position{line: 42, col: 23, offset: 901},
 									val:        "]",
 									ignoreCase: false,
 									want:       "\"]\"",
@@ -256,25 +350,25 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 33, col: 5, offset: 661},
+						pos: position{line: 44, col: 5, offset: 955},
 						run: (*parser).callonVector8,
 						expr: &seqExpr{
-							pos: position{line: 33, col: 5, offset: 661},
+							pos: position{line: 44, col: 5, offset: 955},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 33, col: 5, offset: 661},
+									pos:        position{line: 44, col: 5, offset: 955},
 									val:        "[",
 									ignoreCase: false,
 									want:       "\"[\"",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 33, col: 9, offset: 665},
+									pos:  position{line: 44, col: 9, offset: 959},
 									name: "Seq",
 								},
 								&notExpr{
-									pos: position{line: 33, col: 13, offset: 669},
+									pos: position{line: 44, col: 13, offset: 963},
 									expr: &litMatcher{
-										pos:        position{line: 33, col: 14, offset: 670},
+										pos:        position{line: 44, col: 14, offset: 964},
 										val:        "]",
 										ignoreCase: false,
 										want:       "\"]\"",
@@ -288,63 +382,63 @@ var g = &grammar{
 		},
 		{
 			name: "Hash",
-			pos:  position{line: 38, col: 1, offset: 751},
+			pos:  position{line: 49, col: 1, offset: 1045},
 			expr: &choiceExpr{
-				pos: position{line: 38, col: 9, offset: 761},
+				pos: position{line: 49, col: 9, offset: 1055},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 38, col: 9, offset: 761},
+						pos: position{line: 49, col: 9, offset: 1055},
 						run: (*parser).callonHash2,
 						expr: &seqExpr{
-							pos: position{line: 38, col: 9, offset: 761},
+							pos: position{line: 49, col: 9, offset: 1055},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 38, col: 9, offset: 761},
+									pos:        position{line: 49, col: 9, offset: 1055},
 									val:        "{",
 									ignoreCase: false,
 									want:       "\"{\"",
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 38, col: 13, offset: 765},
+									pos: position{line: 49, col: 13, offset: 1059},
 									expr: &ruleRefExpr{
-										pos:  position{line: 38, col: 13, offset: 765},
+										pos:  position{line: 49, col: 13, offset: 1059},
 										name: "_",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 38, col: 16, offset: 768},
+									pos:   position{line: 49, col: 16, offset: 1062},
 									label: "first",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 38, col: 22, offset: 774},
+										pos: position{line: 49, col: 22, offset: 1068},
 										expr: &seqExpr{
-											pos: position{line: 38, col: 23, offset: 775},
+											pos: position{line: 49, col: 23, offset: 1069},
 											exprs: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 38, col: 23, offset: 775},
+													pos:  position{line: 49, col: 23, offset: 1069},
 													name: "String",
 												},
 												&zeroOrMoreExpr{
-													pos: position{line: 38, col: 30, offset: 782},
+													pos: position{line: 49, col: 30, offset: 1076},
 													expr: &ruleRefExpr{
-														pos:  position{line: 38, col: 30, offset: 782},
+														pos:  position{line: 49, col: 30, offset: 1076},
 														name: "_",
 													},
 												},
 												&litMatcher{
-													pos:        position{line: 38, col: 33, offset: 785},
+													pos:        position{line: 49, col: 33, offset: 1079},
 													val:        ":",
 													ignoreCase: false,
 													want:       "\":\"",
 												},
 												&zeroOrMoreExpr{
-													pos: position{line: 38, col: 37, offset: 789},
+													pos: position{line: 49, col: 37, offset: 1083},
 													expr: &ruleRefExpr{
-														pos:  position{line: 38, col: 37, offset: 789},
+														pos:  position{line: 49, col: 37, offset: 1083},
 														name: "_",
 													},
 												},
 												&ruleRefExpr{
-													pos:  position{line: 38, col: 40, offset: 792},
+													pos:  position{line: 49, col: 40, offset: 1086},
 													name: "Any",
 												},
 											},
@@ -352,46 +446,46 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 38, col: 46, offset: 798},
+									pos:   position{line: 49, col: 46, offset: 1092},
 									label: "rest",
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 38, col: 51, offset: 803},
+										pos: position{line: 49, col: 51, offset: 1097},
 										expr: &seqExpr{
-											pos: position{line: 38, col: 52, offset: 804},
+											pos: position{line: 49, col: 52, offset: 1098},
 											exprs: []interface{}{
 												&oneOrMoreExpr{
-													pos: position{line: 38, col: 52, offset: 804},
+													pos: position{line: 49, col: 52, offset: 1098},
 													expr: &ruleRefExpr{
-														pos:  position{line: 38, col: 52, offset: 804},
+														pos:  position{line: 49, col: 52, offset: 1098},
 														name: "_",
 													},
 												},
 												&ruleRefExpr{
-													pos:  position{line: 38, col: 55, offset: 807},
+													pos:  position{line: 49, col: 55, offset: 1101},
 													name: "String",
 												},
 												&zeroOrMoreExpr{
-													pos: position{line: 38, col: 62, offset: 814},
+													pos: position{line: 49, col: 62, offset: 1108},
 													expr: &ruleRefExpr{
-														pos:  position{line: 38, col: 62, offset: 814},
+														pos:  position{line: 49, col: 62, offset: 1108},
 														name: "_",
 													},
 												},
 												&litMatcher{
-													pos:        position{line: 38, col: 65, offset: 817},
+													pos:        position{line: 49, col: 65, offset: 1111},
 													val:        ":",
 													ignoreCase: false,
 													want:       "\":\"",
 												},
 												&zeroOrMoreExpr{
-													pos: position{line: 38, col: 69, offset: 821},
+													pos: position{line: 49, col: 69, offset: 1115},
 													expr: &ruleRefExpr{
-														pos:  position{line: 38, col: 69, offset: 821},
+														pos:  position{line: 49, col: 69, offset: 1115},
 														name: "_",
 													},
 												},
 												&ruleRefExpr{
-													pos:  position{line: 38, col: 72, offset: 824},
+													pos:  position{line: 49, col: 72, offset: 1118},
 													name: "Any",
 												},
 											},
@@ -399,14 +493,14 @@ var g = &grammar{
 									},
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 38, col: 78, offset: 830},
+									pos: position{line: 49, col: 78, offset: 1124},
 									expr: &ruleRefExpr{
-										pos:  position{line: 38, col: 78, offset: 830},
+										pos:  position{line: 49, col: 78, offset: 1124},
 										name: "_",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 38, col: 81, offset: 833},
+									pos:        position{line: 49, col: 81, offset: 1127},
 									val:        "}",
 									ignoreCase: false,
 									want:       "\"}\"",
@@ -415,111 +509,111 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 40, col: 5, offset: 893},
+						pos: position{line: 51, col: 5, offset: 1171},
 						run: (*parser).callonHash32,
 						expr: &seqExpr{
-							pos: position{line: 40, col: 5, offset: 893},
+							pos: position{line: 51, col: 5, offset: 1171},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 40, col: 5, offset: 893},
+									pos:        position{line: 51, col: 5, offset: 1171},
 									val:        "{",
 									ignoreCase: false,
 									want:       "\"{\"",
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 40, col: 9, offset: 897},
+									pos: position{line: 51, col: 9, offset: 1175},
 									expr: &ruleRefExpr{
-										pos:  position{line: 40, col: 9, offset: 897},
+										pos:  position{line: 51, col: 9, offset: 1175},
 										name: "_",
 									},
 								},
 								&seqExpr{
-									pos: position{line: 40, col: 13, offset: 901},
+									pos: position{line: 51, col: 13, offset: 1179},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 40, col: 13, offset: 901},
+											pos:  position{line: 51, col: 13, offset: 1179},
 											name: "String",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 40, col: 20, offset: 908},
+											pos: position{line: 51, col: 20, offset: 1186},
 											expr: &ruleRefExpr{
-												pos:  position{line: 40, col: 20, offset: 908},
+												pos:  position{line: 51, col: 20, offset: 1186},
 												name: "_",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 40, col: 23, offset: 911},
+											pos:        position{line: 51, col: 23, offset: 1189},
 											val:        ":",
 											ignoreCase: false,
 											want:       "\":\"",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 40, col: 27, offset: 915},
+											pos: position{line: 51, col: 27, offset: 1193},
 											expr: &ruleRefExpr{
-												pos:  position{line: 40, col: 27, offset: 915},
+												pos:  position{line: 51, col: 27, offset: 1193},
 												name: "_",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 40, col: 30, offset: 918},
+											pos:  position{line: 51, col: 30, offset: 1196},
 											name: "Any",
 										},
 									},
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 40, col: 35, offset: 923},
+									pos: position{line: 51, col: 35, offset: 1201},
 									expr: &seqExpr{
-										pos: position{line: 40, col: 36, offset: 924},
+										pos: position{line: 51, col: 36, offset: 1202},
 										exprs: []interface{}{
 											&oneOrMoreExpr{
-												pos: position{line: 40, col: 36, offset: 924},
+												pos: position{line: 51, col: 36, offset: 1202},
 												expr: &ruleRefExpr{
-													pos:  position{line: 40, col: 36, offset: 924},
+													pos:  position{line: 51, col: 36, offset: 1202},
 													name: "_",
 												},
 											},
 											&ruleRefExpr{
-												pos:  position{line: 40, col: 39, offset: 927},
+												pos:  position{line: 51, col: 39, offset: 1205},
 												name: "String",
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 40, col: 46, offset: 934},
+												pos: position{line: 51, col: 46, offset: 1212},
 												expr: &ruleRefExpr{
-													pos:  position{line: 40, col: 46, offset: 934},
+													pos:  position{line: 51, col: 46, offset: 1212},
 													name: "_",
 												},
 											},
 											&litMatcher{
-												pos:        position{line: 40, col: 49, offset: 937},
+												pos:        position{line: 51, col: 49, offset: 1215},
 												val:        ":",
 												ignoreCase: false,
 												want:       "\":\"",
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 40, col: 53, offset: 941},
+												pos: position{line: 51, col: 53, offset: 1219},
 												expr: &ruleRefExpr{
-													pos:  position{line: 40, col: 53, offset: 941},
+													pos:  position{line: 51, col: 53, offset: 1219},
 													name: "_",
 												},
 											},
 											&ruleRefExpr{
-												pos:  position{line: 40, col: 56, offset: 944},
+												pos:  position{line: 51, col: 56, offset: 1222},
 												name: "Any",
 											},
 										},
 									},
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 40, col: 62, offset: 950},
+									pos: position{line: 51, col: 62, offset: 1228},
 									expr: &ruleRefExpr{
-										pos:  position{line: 40, col: 62, offset: 950},
+										pos:  position{line: 51, col: 62, offset: 1228},
 										name: "_",
 									},
 								},
 								&notExpr{
-									pos: position{line: 40, col: 65, offset: 953},
+									pos: position{line: 51, col: 65, offset: 1231},
 									expr: &litMatcher{
-										pos:        position{line: 40, col: 66, offset: 954},
+										pos:        position{line: 51, col: 66, offset: 1232},
 										val:        "}",
 										ignoreCase: false,
 										want:       "\"}\"",
@@ -533,44 +627,44 @@ var g = &grammar{
 		},
 		{
 			name: "Number",
-			pos:  position{line: 45, col: 1, offset: 1047},
+			pos:  position{line: 56, col: 1, offset: 1325},
 			expr: &actionExpr{
-				pos: position{line: 45, col: 11, offset: 1059},
+				pos: position{line: 56, col: 11, offset: 1337},
 				run: (*parser).callonNumber1,
 				expr: &seqExpr{
-					pos: position{line: 45, col: 11, offset: 1059},
+					pos: position{line: 56, col: 11, offset: 1337},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 45, col: 11, offset: 1059},
+							pos: position{line: 56, col: 11, offset: 1337},
 							expr: &litMatcher{
-								pos:        position{line: 45, col: 11, offset: 1059},
+								pos:        position{line: 56, col: 11, offset: 1337},
 								val:        "-",
 								ignoreCase: false,
 								want:       "\"-\"",
 							},
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 45, col: 16, offset: 1064},
+							pos: position{line: 56, col: 16, offset: 1342},
 							expr: &ruleRefExpr{
-								pos:  position{line: 45, col: 16, offset: 1064},
+								pos:  position{line: 56, col: 16, offset: 1342},
 								name: "digit",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 45, col: 23, offset: 1071},
+							pos: position{line: 56, col: 23, offset: 1349},
 							expr: &seqExpr{
-								pos: position{line: 45, col: 24, offset: 1072},
+								pos: position{line: 56, col: 24, offset: 1350},
 								exprs: []interface{}{
 									&litMatcher{
-										pos:        position{line: 45, col: 24, offset: 1072},
+										pos:        position{line: 56, col: 24, offset: 1350},
 										val:        ".",
 										ignoreCase: false,
 										want:       "\".\"",
 									},
 									&oneOrMoreExpr{
-										pos: position{line: 45, col: 28, offset: 1076},
+										pos: position{line: 56, col: 28, offset: 1354},
 										expr: &ruleRefExpr{
-											pos:  position{line: 45, col: 28, offset: 1076},
+											pos:  position{line: 56, col: 28, offset: 1354},
 											name: "digit",
 										},
 									},
@@ -578,29 +672,29 @@ var g = &grammar{
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 45, col: 37, offset: 1085},
+							pos: position{line: 56, col: 37, offset: 1363},
 							expr: &seqExpr{
-								pos: position{line: 45, col: 38, offset: 1086},
+								pos: position{line: 56, col: 38, offset: 1364},
 								exprs: []interface{}{
 									&litMatcher{
-										pos:        position{line: 45, col: 38, offset: 1086},
+										pos:        position{line: 56, col: 38, offset: 1364},
 										val:        "e",
 										ignoreCase: true,
 										want:       "\"e\"i",
 									},
 									&zeroOrOneExpr{
-										pos: position{line: 45, col: 43, offset: 1091},
+										pos: position{line: 56, col: 43, offset: 1369},
 										expr: &choiceExpr{
-											pos: position{line: 45, col: 44, offset: 1092},
+											pos: position{line: 56, col: 44, offset: 1370},
 											alternatives: []interface{}{
 												&litMatcher{
-													pos:        position{line: 45, col: 44, offset: 1092},
+													pos:        position{line: 56, col: 44, offset: 1370},
 													val:        "+",
 													ignoreCase: false,
 													want:       "\"+\"",
 												},
 												&litMatcher{
-													pos:        position{line: 45, col: 50, offset: 1098},
+													pos:        position{line: 56, col: 50, offset: 1376},
 													val:        "-",
 													ignoreCase: false,
 													want:       "\"-\"",
@@ -609,9 +703,9 @@ var g = &grammar{
 										},
 									},
 									&oneOrMoreExpr{
-										pos: position{line: 45, col: 56, offset: 1104},
+										pos: position{line: 56, col: 56, offset: 1382},
 										expr: &ruleRefExpr{
-											pos:  position{line: 45, col: 56, offset: 1104},
+											pos:  position{line: 56, col: 56, offset: 1382},
 											name: "digit",
 										},
 									},
@@ -624,31 +718,31 @@ var g = &grammar{
 		},
 		{
 			name: "String",
-			pos:  position{line: 50, col: 1, offset: 1186},
+			pos:  position{line: 61, col: 1, offset: 1464},
 			expr: &choiceExpr{
-				pos: position{line: 50, col: 11, offset: 1198},
+				pos: position{line: 61, col: 11, offset: 1476},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 50, col: 11, offset: 1198},
+						pos: position{line: 61, col: 11, offset: 1476},
 						run: (*parser).callonString2,
 						expr: &seqExpr{
-							pos: position{line: 50, col: 11, offset: 1198},
+							pos: position{line: 61, col: 11, offset: 1476},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 50, col: 11, offset: 1198},
+									pos:        position{line: 61, col: 11, offset: 1476},
 									val:        "\"",
 									ignoreCase: false,
 									want:       "\"\\\"\"",
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 50, col: 15, offset: 1202},
+									pos: position{line: 61, col: 15, offset: 1480},
 									expr: &ruleRefExpr{
-										pos:  position{line: 50, col: 15, offset: 1202},
+										pos:  position{line: 61, col: 15, offset: 1480},
 										name: "runeChr",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 50, col: 24, offset: 1211},
+									pos:        position{line: 61, col: 24, offset: 1489},
 									val:        "\"",
 									ignoreCase: false,
 									want:       "\"\\\"\"",
@@ -657,28 +751,28 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 52, col: 5, offset: 1273},
+						pos: position{line: 63, col: 5, offset: 1551},
 						run: (*parser).callonString8,
 						expr: &seqExpr{
-							pos: position{line: 52, col: 5, offset: 1273},
+							pos: position{line: 63, col: 5, offset: 1551},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 52, col: 5, offset: 1273},
+									pos:        position{line: 63, col: 5, offset: 1551},
 									val:        "\"",
 									ignoreCase: false,
 									want:       "\"\\\"\"",
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 52, col: 9, offset: 1277},
+									pos: position{line: 63, col: 9, offset: 1555},
 									expr: &ruleRefExpr{
-										pos:  position{line: 52, col: 9, offset: 1277},
+										pos:  position{line: 63, col: 9, offset: 1555},
 										name: "runeChr",
 									},
 								},
 								&notExpr{
-									pos: position{line: 52, col: 18, offset: 1286},
+									pos: position{line: 63, col: 18, offset: 1564},
 									expr: &litMatcher{
-										pos:        position{line: 52, col: 19, offset: 1287},
+										pos:        position{line: 63, col: 19, offset: 1565},
 										val:        "\"",
 										ignoreCase: false,
 										want:       "\"\\\"\"",
@@ -692,19 +786,19 @@ var g = &grammar{
 		},
 		{
 			name: "runeChr",
-			pos:  position{line: 56, col: 1, offset: 1437},
+			pos:  position{line: 67, col: 1, offset: 1715},
 			expr: &choiceExpr{
-				pos: position{line: 56, col: 12, offset: 1450},
+				pos: position{line: 67, col: 12, offset: 1728},
 				alternatives: []interface{}{
 					&charClassMatcher{
-						pos:        position{line: 56, col: 12, offset: 1450},
+						pos:        position{line: 67, col: 12, offset: 1728},
 						val:        "[^\"\\\\]",
 						chars:      []rune{'"', '\\'},
 						ignoreCase: false,
 						inverted:   true,
 					},
 					&ruleRefExpr{
-						pos:  position{line: 56, col: 21, offset: 1459},
+						pos:  position{line: 67, col: 21, offset: 1737},
 						name: "runeEsc",
 					},
 				},
@@ -712,111 +806,111 @@ var g = &grammar{
 		},
 		{
 			name: "runeEsc",
-			pos:  position{line: 57, col: 1, offset: 1467},
+			pos:  position{line: 68, col: 1, offset: 1745},
 			expr: &seqExpr{
-				pos: position{line: 57, col: 12, offset: 1480},
+				pos: position{line: 68, col: 12, offset: 1758},
 				exprs: []interface{}{
 					&litMatcher{
-						pos:        position{line: 57, col: 12, offset: 1480},
+						pos:        position{line: 68, col: 12, offset: 1758},
 						val:        "\\",
 						ignoreCase: false,
 						want:       "\"\\\\\"",
 					},
 					&choiceExpr{
-						pos: position{line: 57, col: 17, offset: 1485},
+						pos: position{line: 68, col: 17, offset: 1763},
 						alternatives: []interface{}{
 							&charClassMatcher{
-								pos:        position{line: 57, col: 17, offset: 1485},
+								pos:        position{line: 68, col: 17, offset: 1763},
 								val:        "[\"\\\\/abfnrtv]",
 								chars:      []rune{'"', '\\', '/', 'a', 'b', 'f', 'n', 'r', 't', 'v'},
 								ignoreCase: false,
 								inverted:   false,
 							},
 							&seqExpr{
-								pos: position{line: 58, col: 13, offset: 1513},
+								pos: position{line: 69, col: 13, offset: 1791},
 								exprs: []interface{}{
 									&litMatcher{
-										pos:        position{line: 58, col: 13, offset: 1513},
+										pos:        position{line: 69, col: 13, offset: 1791},
 										val:        "x",
 										ignoreCase: false,
 										want:       "\"x\"",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 58, col: 17, offset: 1517},
+										pos:  position{line: 69, col: 17, offset: 1795},
 										name: "hexDigit",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 58, col: 26, offset: 1526},
+										pos:  position{line: 69, col: 26, offset: 1804},
 										name: "hexDigit",
 									},
 								},
 							},
 							&seqExpr{
-								pos: position{line: 59, col: 13, offset: 1550},
+								pos: position{line: 70, col: 13, offset: 1828},
 								exprs: []interface{}{
 									&litMatcher{
-										pos:        position{line: 59, col: 13, offset: 1550},
+										pos:        position{line: 70, col: 13, offset: 1828},
 										val:        "u",
 										ignoreCase: false,
 										want:       "\"u\"",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 59, col: 17, offset: 1554},
+										pos:  position{line: 70, col: 17, offset: 1832},
 										name: "hexDigit",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 59, col: 26, offset: 1563},
+										pos:  position{line: 70, col: 26, offset: 1841},
 										name: "hexDigit",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 59, col: 35, offset: 1572},
+										pos:  position{line: 70, col: 35, offset: 1850},
 										name: "hexDigit",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 59, col: 44, offset: 1581},
+										pos:  position{line: 70, col: 44, offset: 1859},
 										name: "hexDigit",
 									},
 								},
 							},
 							&seqExpr{
-								pos: position{line: 60, col: 13, offset: 1605},
+								pos: position{line: 71, col: 13, offset: 1883},
 								exprs: []interface{}{
 									&litMatcher{
-										pos:        position{line: 60, col: 13, offset: 1605},
+										pos:        position{line: 71, col: 13, offset: 1883},
 										val:        "U",
 										ignoreCase: false,
 										want:       "\"U\"",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 60, col: 17, offset: 1609},
+										pos:  position{line: 71, col: 17, offset: 1887},
 										name: "hexDigit",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 60, col: 26, offset: 1618},
+										pos:  position{line: 71, col: 26, offset: 1896},
 										name: "hexDigit",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 60, col: 35, offset: 1627},
+										pos:  position{line: 71, col: 35, offset: 1905},
 										name: "hexDigit",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 60, col: 44, offset: 1636},
+										pos:  position{line: 71, col: 44, offset: 1914},
 										name: "hexDigit",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 60, col: 53, offset: 1645},
+										pos:  position{line: 71, col: 53, offset: 1923},
 										name: "hexDigit",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 60, col: 62, offset: 1654},
+										pos:  position{line: 71, col: 62, offset: 1932},
 										name: "hexDigit",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 60, col: 71, offset: 1663},
+										pos:  position{line: 71, col: 71, offset: 1941},
 										name: "hexDigit",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 60, col: 80, offset: 1672},
+										pos:  position{line: 71, col: 80, offset: 1950},
 										name: "hexDigit",
 									},
 								},
@@ -828,9 +922,9 @@ var g = &grammar{
 		},
 		{
 			name: "hexDigit",
-			pos:  position{line: 61, col: 1, offset: 1683},
+			pos:  position{line: 72, col: 1, offset: 1961},
 			expr: &charClassMatcher{
-				pos:        position{line: 61, col: 12, offset: 1696},
+				pos:        position{line: 72, col: 12, offset: 1974},
 				val:        "[0-9a-f]i",
 				ranges:     []rune{'0', '9', 'a', 'f'},
 				ignoreCase: true,
@@ -839,41 +933,75 @@ var g = &grammar{
 		},
 		{
 			name: "Symbol",
-			pos:  position{line: 64, col: 1, offset: 1754},
+			pos:  position{line: 75, col: 1, offset: 2032},
 			expr: &actionExpr{
-				pos: position{line: 64, col: 11, offset: 1766},
+				pos: position{line: 75, col: 11, offset: 2044},
 				run: (*parser).callonSymbol1,
-				expr: &seqExpr{
-					pos: position{line: 64, col: 11, offset: 1766},
-					exprs: []interface{}{
-						&ruleRefExpr{
-							pos:  position{line: 64, col: 11, offset: 1766},
-							name: "word",
-						},
-						&zeroOrMoreExpr{
-							pos: position{line: 64, col: 16, offset: 1771},
-							expr: &seqExpr{
-								pos: position{line: 64, col: 17, offset: 1772},
-								exprs: []interface{}{
-									&litMatcher{
-										pos:        position{line: 64, col: 17, offset: 1772},
-										val:        ".",
-										ignoreCase: false,
-										want:       "\".\"",
+				expr: &choiceExpr{
+					pos: position{line: 75, col: 12, offset: 2045},
+					alternatives: []interface{}{
+						&seqExpr{
+							pos: position{line: 75, col: 12, offset: 2045},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 75, col: 12, offset: 2045},
+									name: "word",
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 75, col: 17, offset: 2050},
+									expr: &seqExpr{
+										pos: position{line: 75, col: 18, offset: 2051},
+										exprs: []interface{}{
+											&litMatcher{
+												pos:        position{line: 75, col: 18, offset: 2051},
+												val:        ".",
+												ignoreCase: false,
+												want:       "\".\"",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 75, col: 22, offset: 2055},
+												name: "word",
+											},
+										},
 									},
-									&ruleRefExpr{
-										pos:  position{line: 64, col: 21, offset: 1776},
-										name: "word",
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 75, col: 29, offset: 2062},
+									expr: &ruleRefExpr{
+										pos:  position{line: 75, col: 29, offset: 2062},
+										name: "suffix",
 									},
 								},
 							},
 						},
-						&zeroOrOneExpr{
-							pos: position{line: 64, col: 28, offset: 1783},
-							expr: &ruleRefExpr{
-								pos:  position{line: 64, col: 28, offset: 1783},
-								name: "suffix",
-							},
+						&litMatcher{
+							pos:        position{line: 75, col: 39, offset: 2072},
+							val:        "&",
+							ignoreCase: false,
+							want:       "\"&\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Keyword",
+			pos:  position{line: 88, col: 1, offset: 2371},
+			expr: &actionExpr{
+				pos: position{line: 88, col: 12, offset: 2384},
+				run: (*parser).callonKeyword1,
+				expr: &seqExpr{
+					pos: position{line: 88, col: 12, offset: 2384},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 88, col: 12, offset: 2384},
+							val:        ":",
+							ignoreCase: false,
+							want:       "\":\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 88, col: 16, offset: 2388},
+							name: "word",
 						},
 					},
 				},
@@ -881,26 +1009,40 @@ var g = &grammar{
 		},
 		{
 			name: "word",
-			pos:  position{line: 77, col: 1, offset: 2072},
+			pos:  position{line: 92, col: 1, offset: 2496},
 			expr: &seqExpr{
-				pos: position{line: 77, col: 9, offset: 2082},
+				pos: position{line: 92, col: 9, offset: 2506},
 				exprs: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 77, col: 9, offset: 2082},
+						pos:  position{line: 92, col: 9, offset: 2506},
 						name: "letter",
 					},
 					&zeroOrMoreExpr{
-						pos: position{line: 77, col: 16, offset: 2089},
-						expr: &choiceExpr{
-							pos: position{line: 77, col: 17, offset: 2090},
-							alternatives: []interface{}{
-								&ruleRefExpr{
-									pos:  position{line: 77, col: 17, offset: 2090},
-									name: "letter",
+						pos: position{line: 92, col: 16, offset: 2513},
+						expr: &seqExpr{
+							pos: position{line: 92, col: 17, offset: 2514},
+							exprs: []interface{}{
+								&zeroOrOneExpr{
+									pos: position{line: 92, col: 17, offset: 2514},
+									expr: &litMatcher{
+										pos:        position{line: 92, col: 17, offset: 2514},
+										val:        "-",
+										ignoreCase: false,
+										want:       "\"-\"",
+									},
 								},
-								&ruleRefExpr{
-									pos:  position{line: 77, col: 26, offset: 2099},
-									name: "digit",
+								&choiceExpr{
+									pos: position{line: 92, col: 23, offset: 2520},
+									alternatives: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 92, col: 23, offset: 2520},
+											name: "letter",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 92, col: 32, offset: 2529},
+											name: "digit",
+										},
+									},
 								},
 							},
 						},
@@ -910,19 +1052,19 @@ var g = &grammar{
 		},
 		{
 			name: "letter",
-			pos:  position{line: 79, col: 1, offset: 2140},
+			pos:  position{line: 94, col: 1, offset: 2571},
 			expr: &choiceExpr{
-				pos: position{line: 79, col: 11, offset: 2152},
+				pos: position{line: 94, col: 11, offset: 2583},
 				alternatives: []interface{}{
 					&charClassMatcher{
-						pos:        position{line: 79, col: 11, offset: 2152},
+						pos:        position{line: 94, col: 11, offset: 2583},
 						val:        "[\\p{L}]",
 						classes:    []*unicode.RangeTable{rangeTable("L")},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&litMatcher{
-						pos:        position{line: 79, col: 21, offset: 2162},
+						pos:        position{line: 94, col: 21, offset: 2593},
 						val:        "_",
 						ignoreCase: false,
 						want:       "\"_\"",
@@ -932,9 +1074,9 @@ var g = &grammar{
 		},
 		{
 			name: "digit",
-			pos:  position{line: 81, col: 1, offset: 2178},
+			pos:  position{line: 96, col: 1, offset: 2609},
 			expr: &charClassMatcher{
-				pos:        position{line: 81, col: 10, offset: 2189},
+				pos:        position{line: 96, col: 10, offset: 2620},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
@@ -943,9 +1085,9 @@ var g = &grammar{
 		},
 		{
 			name: "suffix",
-			pos:  position{line: 83, col: 1, offset: 2212},
+			pos:  position{line: 98, col: 1, offset: 2643},
 			expr: &charClassMatcher{
-				pos:        position{line: 83, col: 11, offset: 2224},
+				pos:        position{line: 98, col: 11, offset: 2655},
 				val:        "[!?*]",
 				chars:      []rune{'!', '?', '*'},
 				ignoreCase: false,
@@ -955,32 +1097,32 @@ var g = &grammar{
 		{
 			name:        "_",
 			displayName: "\"whitespace\"",
-			pos:         position{line: 86, col: 1, offset: 2283},
+			pos:         position{line: 101, col: 1, offset: 2714},
 			expr: &choiceExpr{
-				pos: position{line: 86, col: 19, offset: 2303},
+				pos: position{line: 101, col: 19, offset: 2734},
 				alternatives: []interface{}{
 					&charClassMatcher{
-						pos:        position{line: 86, col: 19, offset: 2303},
+						pos:        position{line: 101, col: 19, offset: 2734},
 						val:        "[\\p{Z}]",
 						classes:    []*unicode.RangeTable{rangeTable("Z")},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&charClassMatcher{
-						pos:        position{line: 86, col: 29, offset: 2313},
+						pos:        position{line: 101, col: 29, offset: 2744},
 						val:        "[\\p{C}]",
 						classes:    []*unicode.RangeTable{rangeTable("C")},
 						ignoreCase: false,
 						inverted:   false,
 					},
 					&litMatcher{
-						pos:        position{line: 86, col: 39, offset: 2323},
+						pos:        position{line: 101, col: 39, offset: 2754},
 						val:        ",",
 						ignoreCase: false,
 						want:       "\",\"",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 86, col: 45, offset: 2329},
+						pos:  position{line: 101, col: 45, offset: 2760},
 						name: "Comment",
 					},
 				},
@@ -988,16 +1130,16 @@ var g = &grammar{
 		},
 		{
 			name: "Comment",
-			pos:  position{line: 89, col: 1, offset: 2350},
+			pos:  position{line: 104, col: 1, offset: 2781},
 			expr: &choiceExpr{
-				pos: position{line: 89, col: 12, offset: 2363},
+				pos: position{line: 104, col: 12, offset: 2794},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 89, col: 12, offset: 2363},
+						pos:  position{line: 104, col: 12, offset: 2794},
 						name: "SingleLineComment",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 89, col: 32, offset: 2383},
+						pos:  position{line: 104, col: 32, offset: 2814},
 						name: "MultiLineComment",
 					},
 				},
@@ -1005,36 +1147,36 @@ var g = &grammar{
 		},
 		{
 			name: "SingleLineComment",
-			pos:  position{line: 90, col: 1, offset: 2400},
+			pos:  position{line: 105, col: 1, offset: 2831},
 			expr: &seqExpr{
-				pos: position{line: 90, col: 21, offset: 2422},
+				pos: position{line: 105, col: 21, offset: 2853},
 				exprs: []interface{}{
 					&litMatcher{
-						pos:        position{line: 90, col: 21, offset: 2422},
+						pos:        position{line: 105, col: 21, offset: 2853},
 						val:        "//",
 						ignoreCase: false,
 						want:       "\"//\"",
 					},
 					&zeroOrMoreExpr{
-						pos: position{line: 90, col: 26, offset: 2427},
+						pos: position{line: 105, col: 26, offset: 2858},
 						expr: &seqExpr{
-							pos: position{line: 90, col: 27, offset: 2428},
+							pos: position{line: 105, col: 27, offset: 2859},
 							exprs: []interface{}{
 								&notExpr{
-									pos: position{line: 90, col: 27, offset: 2428},
+									pos: position{line: 105, col: 27, offset: 2859},
 									expr: &ruleRefExpr{
-										pos:  position{line: 90, col: 28, offset: 2429},
+										pos:  position{line: 105, col: 28, offset: 2860},
 										name: "EOL",
 									},
 								},
 								&anyMatcher{
-									line: 90, col: 32, offset: 2433,
+									line: 105, col: 32, offset: 2864,
 								},
 							},
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 90, col: 36, offset: 2437},
+						pos:  position{line: 105, col: 36, offset: 2868},
 						name: "EOL",
 					},
 				},
@@ -1042,38 +1184,38 @@ var g = &grammar{
 		},
 		{
 			name: "MultiLineComment",
-			pos:  position{line: 91, col: 1, offset: 2441},
+			pos:  position{line: 106, col: 1, offset: 2872},
 			expr: &seqExpr{
-				pos: position{line: 91, col: 21, offset: 2463},
+				pos: position{line: 106, col: 21, offset: 2894},
 				exprs: []interface{}{
 					&litMatcher{
-						pos:        position{line: 91, col: 21, offset: 2463},
+						pos:        position{line: 106, col: 21, offset: 2894},
 						val:        "/*",
 						ignoreCase: false,
 						want:       "\"/*\"",
 					},
 					&zeroOrMoreExpr{
-						pos: position{line: 91, col: 26, offset: 2468},
+						pos: position{line: 106, col: 26, offset: 2899},
 						expr: &seqExpr{
-							pos: position{line: 91, col: 27, offset: 2469},
+							pos: position{line: 106, col: 27, offset: 2900},
 							exprs: []interface{}{
 								&notExpr{
-									pos: position{line: 91, col: 27, offset: 2469},
+									pos: position{line: 106, col: 27, offset: 2900},
 									expr: &litMatcher{
-										pos:        position{line: 91, col: 28, offset: 2470},
+										pos:        position{line: 106, col: 28, offset: 2901},
 										val:        "*/",
 										ignoreCase: false,
 										want:       "\"*/\"",
 									},
 								},
 								&anyMatcher{
-									line: 91, col: 33, offset: 2475,
+									line: 106, col: 33, offset: 2906,
 								},
 							},
 						},
 					},
 					&litMatcher{
-						pos:        position{line: 91, col: 37, offset: 2479},
+						pos:        position{line: 106, col: 37, offset: 2910},
 						val:        "*/",
 						ignoreCase: false,
 						want:       "\"*/\"",
@@ -1083,18 +1225,18 @@ var g = &grammar{
 		},
 		{
 			name: "EOL",
-			pos:  position{line: 94, col: 1, offset: 2500},
+			pos:  position{line: 109, col: 1, offset: 2931},
 			expr: &choiceExpr{
-				pos: position{line: 94, col: 8, offset: 2509},
+				pos: position{line: 109, col: 8, offset: 2940},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 94, col: 8, offset: 2509},
+						pos:        position{line: 109, col: 8, offset: 2940},
 						val:        "\n",
 						ignoreCase: false,
 						want:       "\"\\n\"",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 94, col: 15, offset: 2516},
+						pos:  position{line: 109, col: 15, offset: 2947},
 						name: "EOF",
 					},
 				},
@@ -1102,11 +1244,11 @@ var g = &grammar{
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 96, col: 1, offset: 2535},
+			pos:  position{line: 111, col: 1, offset: 2966},
 			expr: &notExpr{
-				pos: position{line: 96, col: 8, offset: 2544},
+				pos: position{line: 111, col: 8, offset: 2975},
 				expr: &anyMatcher{
-					line: 96, col: 9, offset: 2545,
+					line: 111, col: 9, offset: 2976,
 				},
 			},
 		},
@@ -1114,7 +1256,7 @@ var g = &grammar{
 }
 
 func (c *current) onModule1(seq interface{}) (interface{}, error) {
-	return core.Expr(seq.([]core.Any)), nil
+	return node(core.Expr(seq.([]core.Any)), c)
 }
 
 func (p *parser) callonModule1() (interface{}, error) {
@@ -1124,7 +1266,7 @@ func (p *parser) callonModule1() (interface{}, error) {
 }
 
 func (c *current) onSeq1(first, rest interface{}) (interface{}, error) {
-	return join(first, rest, 1), nil
+	return join(first, rest, 1)
 }
 
 func (p *parser) callonSeq1() (interface{}, error) {
@@ -1133,6 +1275,16 @@ func (p *parser) callonSeq1() (interface{}, error) {
 	return p.cur.onSeq1(stack["first"], stack["rest"])
 }
 
+func (c *current) onAny1(any interface{}) (interface{}, error) {
+	return node(any, c)
+}
+
+func (p *parser) callonAny1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAny1(stack["any"])
+}
+
 func (c *current) onExpr2(seq interface{}) (interface{}, error) {
 	return core.Expr(seq.([]core.Any)), nil
 }
@@ -1153,6 +1305,36 @@ func (p *parser) callonExpr8() (interface{}, error) {
 	return p.cur.onExpr8()
 }
 
+func (c *current) onQuasi2(form interface{}) (interface{}, error) {
+	return wrap("quasiquote", form, c)
+}
+
+func (p *parser) callonQuasi2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onQuasi2(stack["form"])
+}
+
+func (c *current) onQuasi7(form interface{}) (interface{}, error) {
+	return wrap("splice-unquote", form, c)
+}
+
+func (p *parser) callonQuasi7() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onQuasi7(stack["form"])
+}
+
+func (c *current) onQuasi12(form interface{}) (interface{}, error) {
+	return wrap("unquote", form, c)
+}
+
+func (p *parser) callonQuasi12() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onQuasi12(stack["form"])
+}
+
 func (c *current) onVector2(seq interface{}) (interface{}, error) {
 	return core.Vector(seq.([]core.Any)), nil
 }
@@ -1174,7 +1356,7 @@ func (p *parser) callonVector8() (interface{}, error) {
 }
 
 func (c *current) onHash2(first, rest interface{}) (interface{}, error) {
-	return core.Hash(merge(first, rest, 0, 4)), nil
+	return merge(first, rest, 0, 4)
 }
 
 func (p *parser) callonHash2() (interface{}, error) {
@@ -1226,7 +1408,7 @@ func (p *parser) callonString8() (interface{}, error) {
 func (c *current) onSymbol1() (interface{}, error) {
 	switch str := string(c.text); {
 	default:
-		return core.NewSymbol(str, pos(c.pos)), nil
+		return core.NewSymbol(str, pos(c)), nil
 	case str == "null":
 		return core.Null{}, nil
 	case str == "true":
@@ -1242,6 +1424,16 @@ func (p *parser) callonSymbol1() (interface{}, error) {
 	return p.cur.onSymbol1()
 }
 
+func (c *current) onKeyword1() (interface{}, error) {
+	return core.Keyword{Val: string(c.text[1:])}, nil
+}
+
+func (p *parser) callonKeyword1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onKeyword1()
+}
+
 var (
 	// errNoRule is returned when the grammar to parse has no rule.
 	errNoRule = errors.New("grammar has no rule")
@@ -31,16 +31,21 @@ import (
 )
 
 func main() {
+	trap()
 	cmd.Execute()
 }
 
 // trap OS termination signals
 func trap() {
-	traps := make(chan os.Signal, 1)
+	traps := make(chan os.Signal, 2)
 	signal.Notify(traps, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		sig := <-traps
 		fmt.Fprintln(os.Stderr, "signal:", sig)
-		cmd.Quit()
+		// graceful: cancel evaluations, run shutdown hooks
+		cmd.Shutdown(sig)
+		sig = <-traps
+		fmt.Fprintln(os.Stderr, "signal:", sig, "(forced exit)")
+		os.Exit(cmd.ExitCode(sig))
 	}()
 }
@@ -0,0 +1,266 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// type:actor
+type Actor struct {
+	*actor
+}
+
+type actor struct {
+	id uint64
+	mu sync.Mutex
+	// unread messages, oldest first
+	mailbox []letter
+	sent    uint64
+	// closed and replaced by each send, waking every receiver
+	changed chan struct{}
+	// closed once reason is set
+	done   chan struct{}
+	reason core.Any
+	// crashes are sent both ways as [:exit actor reason]
+	links map[*actor]bool
+	// sent [:down actor reason] on any exit
+	monitors map[*actor]bool
+	// ctx that actors spawned by this one derive from
+	root   context.Context
+	cancel context.CancelFunc
+	killed bool
+	// of the sandbox the actor runs in, nil when unlimited
+	quota *quota
+}
+
+var actorCount uint64
+
+type actorKey struct{}
+
+// message numbered in order sent
+type letter struct {
+	seq uint64
+	msg core.Any
+}
+
+// actor with a mailbox and the ctx to run it in, cancelled with ctx, by
+// Kill or on exit; one spawned by an actor outlives it, so derives from
+// the ctx its parent was spawned with instead. Its messages count against
+// the alloc quota of env
+func NewActor(ctx context.Context, env *Env) (Actor, context.Context) {
+	if parent, ok := ActorOf(ctx); ok {
+		ctx = parent.root
+	}
+	root := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	a := Actor{&actor{
+		id:       atomic.AddUint64(&actorCount, 1),
+		changed:  make(chan struct{}),
+		done:     make(chan struct{}),
+		links:    make(map[*actor]bool),
+		monitors: make(map[*actor]bool),
+		root:     root,
+		cancel:   cancel,
+		quota:    env.quota,
+	}}
+	return a, context.WithValue(ctx, actorKey{}, a)
+}
+
+// actor running the evaluation of ctx
+func ActorOf(ctx context.Context) (Actor, bool) {
+	a, ok := ctx.Value(actorKey{}).(Actor)
+	return a, ok
+}
+
+// actor of evaluation outside any spawned one, one per env tree
+type mainActor struct {
+	once  sync.Once
+	actor Actor
+}
+
+// actor evaluating in env: the one running its context, else the main
+// actor of its env tree, created on first use
+func (env *Env) Self() Actor {
+	if a, ok := ActorOf(env.Context()); ok {
+		return a
+	}
+	env.main.once.Do(func() {
+		env.main.actor, _ = NewActor(context.Background(), env)
+	})
+	return env.main.actor
+}
+
+func (a Actor) String() string {
+	return fmt.Sprintf("#<actor %d>", a.id)
+}
+
+func (a Actor) GoString() string {
+	return a.String()
+}
+
+func (a Actor) Equal(any core.Any) bool {
+	switch arg := any.(type) {
+	default:
+		return false
+	case Actor:
+		return a.actor == arg.actor
+	}
+}
+
+// exit reason, ok is false while running
+func (a Actor) Reason() (core.Any, bool) {
+	select {
+	case <-a.done:
+		return a.reason, true
+	default:
+		return core.Null{}, false
+	}
+}
+
+// false once a has exited, or an error once its mailbox exceeds the alloc
+// quota of a's sandbox
+func (a Actor) Send(msg core.Any) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, exited := a.Reason(); exited {
+		return false, nil
+	}
+	if a.quota != nil {
+		if err := a.quota.allocate(1); err != nil {
+			return false, err
+		}
+	}
+	a.sent++
+	a.mailbox = append(a.mailbox, letter{seq: a.sent, msg: msg})
+	close(a.changed)
+	a.changed = make(chan struct{})
+	return true, nil
+}
+
+// remove and return the oldest message that match accepts, waiting for
+// one until timeout fires, when ok is false
+func (a Actor) Receive(ctx context.Context, match func(msg core.Any) (bool, error), timeout <-chan time.Time) (msg core.Any, ok bool, err error) {
+scan:
+	for {
+		a.mu.Lock()
+		mailbox, changed := a.mailbox, a.changed
+		a.mu.Unlock()
+		// matched without the lock, a guard may send to a
+		for _, letter := range mailbox {
+			ok, err := match(letter.msg)
+			if err != nil {
+				return core.Null{}, false, err
+			}
+			if ok {
+				if !a.take(letter.seq) {
+					// taken by another receiver
+					continue scan
+				}
+				return letter.msg, true, nil
+			}
+		}
+		select {
+		case <-changed:
+		case <-timeout:
+			return core.Null{}, false, nil
+		case <-ctx.Done():
+			return core.Null{}, false, CheckContext(ctx)
+		}
+	}
+}
+
+// remove message seq, false when already removed
+func (a Actor) take(seq uint64) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, letter := range a.mailbox {
+		if letter.seq == seq {
+			a.mailbox = append(a.mailbox[:i:i], a.mailbox[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// stop the evaluation a runs, it exits with :killed
+func (a Actor) Kill() {
+	a.mu.Lock()
+	a.killed = true
+	a.mu.Unlock()
+	a.cancel()
+}
+
+func (a Actor) Killed() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.killed
+}
+
+// :normal for a clean exit
+func normal(reason core.Any) bool {
+	return reason.Equal(core.Keyword{Val: "normal"})
+}
+
+// set the exit reason and notify links and monitors, once
+func (a Actor) Exit(reason core.Any) {
+	a.mu.Lock()
+	if _, exited := a.Reason(); exited {
+		a.mu.Unlock()
+		return
+	}
+	a.reason = reason
+	close(a.done)
+	links, monitors := a.links, a.monitors
+	a.links, a.monitors = nil, nil
+	a.mu.Unlock()
+	a.cancel()
+	for other := range links {
+		other.mu.Lock()
+		delete(other.links, a.actor)
+		other.mu.Unlock()
+		if !normal(reason) {
+			Actor{other}.Send(core.Vector{core.Keyword{Val: "exit"}, a, reason})
+		}
+	}
+	for other := range monitors {
+		Actor{other}.Send(core.Vector{core.Keyword{Val: "down"}, a, reason})
+	}
+}
+
+// link a and other both ways, at once if other already crashed
+func (a Actor) Link(other Actor) {
+	other.mu.Lock()
+	if other.links == nil {
+		// exited
+		reason := other.reason
+		other.mu.Unlock()
+		if !normal(reason) {
+			a.Send(core.Vector{core.Keyword{Val: "exit"}, other, reason})
+		}
+		return
+	}
+	other.links[a.actor] = true
+	other.mu.Unlock()
+	a.mu.Lock()
+	if a.links != nil {
+		a.links[other.actor] = true
+	}
+	a.mu.Unlock()
+}
+
+// a is sent [:down other reason] when other exits, at once if it has
+func (a Actor) Monitor(other Actor) {
+	other.mu.Lock()
+	if other.monitors != nil {
+		other.monitors[a.actor] = true
+		other.mu.Unlock()
+		return
+	}
+	other.mu.Unlock()
+	a.Send(core.Vector{core.Keyword{Val: "down"}, other, other.reason})
+}
@@ -0,0 +1,119 @@
+package base
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// type:atom
+type Atom struct {
+	*atom
+}
+
+type atom struct {
+	id  uint64
+	mu  sync.Mutex
+	val core.Any
+	// bumped by every change, for compare-and-set
+	version   uint64
+	validator Func
+	watches   map[string]Watch
+}
+
+// called with key, atom, old and new value after each change
+type Watch struct {
+	Key core.Any
+	Fn  Func
+}
+
+// numbers atoms for printing
+var atomCount uint64
+
+func NewAtom(val core.Any) Atom {
+	id := atomic.AddUint64(&atomCount, 1)
+	return Atom{&atom{id: id, val: val, watches: make(map[string]Watch)}}
+}
+
+// without its value, which may hold the atom itself
+func (a Atom) String() string {
+	return fmt.Sprintf("#<atom %d>", a.id)
+}
+
+func (a Atom) GoString() string {
+	return a.String()
+}
+
+func (a Atom) Equal(any core.Any) bool {
+	switch arg := any.(type) {
+	default:
+		return false
+	case Atom:
+		return a.atom == arg.atom
+	}
+}
+
+// current value and its version
+func (a Atom) Deref() (core.Any, uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.val, a.version
+}
+
+// set val unless changed since version, returning the old value
+func (a Atom) SetIf(version uint64, val core.Any) (core.Any, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.version != version {
+		return a.val, false
+	}
+	old := a.val
+	a.val = val
+	a.version++
+	return old, true
+}
+
+// nil when unset
+func (a Atom) Validator() Func {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.validator
+}
+
+func (a Atom) SetValidator(fn Func) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.validator = fn
+}
+
+// replaces any watch with the same key
+func (a Atom) AddWatch(key core.Any, fn Func) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.watches[key.GoString()] = Watch{Key: key, Fn: fn}
+}
+
+func (a Atom) RemoveWatch(key core.Any) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.watches, key.GoString())
+}
+
+// watches by key
+func (a Atom) Watches() []Watch {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	keys := make([]string, 0, len(a.watches))
+	for key := range a.watches {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	res := make([]Watch, len(keys))
+	for i, key := range keys {
+		res[i] = a.watches[key]
+	}
+	return res
+}
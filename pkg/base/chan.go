@@ -0,0 +1,205 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// what a full buffered chan does with a put
+const (
+	// wait for room
+	Block = ""
+	// drop the new value
+	Dropping = "dropping"
+	// drop the oldest value
+	Sliding = "sliding"
+)
+
+// type:chan
+type Chan struct {
+	*channel
+}
+
+type channel struct {
+	ch   chan core.Any
+	mode string
+	// closed by close!, never ch itself so blocked puts cannot panic
+	done chan struct{}
+	once sync.Once
+	// serializes sliding puts
+	mu sync.Mutex
+}
+
+// unbuffered when size is 0
+func NewChan(size int, mode string) (Chan, error) {
+	if size < 0 {
+		return Chan{}, fmt.Errorf("negative buffer size %d", size)
+	}
+	switch mode {
+	default:
+		return Chan{}, fmt.Errorf("unknown buffer mode %q", mode)
+	case Block:
+		break
+	case Dropping, Sliding:
+		if size == 0 {
+			return Chan{}, fmt.Errorf("%s chan without a buffer", mode)
+		}
+	}
+	return Chan{&channel{ch: make(chan core.Any, size), mode: mode, done: make(chan struct{})}}, nil
+}
+
+func (c Chan) String() string {
+	return "&chan"
+}
+
+func (c Chan) GoString() string {
+	if c.mode != Block {
+		return fmt.Sprintf("&chan<%d %s>", cap(c.ch), c.mode)
+	}
+	return fmt.Sprintf("&chan<%d>", cap(c.ch))
+}
+
+func (c Chan) Equal(any core.Any) bool {
+	switch arg := any.(type) {
+	default:
+		return false
+	case Chan:
+		return c.channel == arg.channel
+	}
+}
+
+func (c Chan) Closed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// no more puts, takes drain the buffer and then get null
+func (c Chan) Close() {
+	c.once.Do(func() {
+		close(c.done)
+	})
+}
+
+// false once closed, dropping and sliding chans never wait
+func (c Chan) Put(ctx context.Context, val core.Any) (bool, error) {
+	if c.Closed() {
+		return false, nil
+	}
+	switch c.mode {
+	case Dropping:
+		select {
+		case c.ch <- val:
+		default:
+		}
+		return true, nil
+	case Sliding:
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for {
+			select {
+			case c.ch <- val:
+				return true, nil
+			default:
+				// make room
+				select {
+				case <-c.ch:
+				default:
+				}
+			}
+		}
+	}
+	select {
+	case c.ch <- val:
+		return true, nil
+	case <-c.done:
+		return false, nil
+	case <-ctx.Done():
+		return false, CheckContext(ctx)
+	}
+}
+
+// next value, or null once closed and drained
+func (c Chan) Take(ctx context.Context) (core.Any, error) {
+	select {
+	case val := <-c.ch:
+		return val, nil
+	case <-c.done:
+		return c.drain(), nil
+	case <-ctx.Done():
+		return core.Null{}, CheckContext(ctx)
+	}
+}
+
+// buffered value left after close, or null
+func (c Chan) drain() core.Any {
+	select {
+	case val := <-c.ch:
+		return val
+	default:
+		return core.Null{}
+	}
+}
+
+// a take from Chan, or a put of Val when Put
+type ChanOp struct {
+	Chan Chan
+	Put  bool
+	Val  core.Any
+}
+
+// run the first ready op, takes get their value and puts whether they
+// were accepted
+func Alts(ctx context.Context, ops []ChanOp) (index int, val core.Any, err error) {
+	cases := make([]reflect.SelectCase, 0, 2*len(ops)+1)
+	for i, op := range ops {
+		if op.Put && op.Chan.Closed() {
+			return i, core.Bool(false), nil
+		}
+		if op.Put && op.Chan.mode != Block {
+			// never waits
+			ok, err := op.Chan.Put(ctx, op.Val)
+			return i, core.Bool(ok), err
+		}
+		if op.Put {
+			cases = append(cases, reflect.SelectCase{
+				Dir:  reflect.SelectSend,
+				Chan: reflect.ValueOf(op.Chan.ch),
+				Send: reflect.ValueOf(&ops[i].Val).Elem(),
+			})
+		} else {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(op.Chan.ch)})
+		}
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(op.Chan.done)})
+	}
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+	chosen, recv, _ := reflect.Select(cases)
+	if chosen == 2*len(ops) {
+		return -1, core.Null{}, CheckContext(ctx)
+	}
+	op := ops[chosen/2]
+	closed := chosen%2 == 1
+	switch {
+	case op.Put:
+		return chosen / 2, core.Bool(!closed), nil
+	case closed:
+		return chosen / 2, op.Chan.drain(), nil
+	default:
+		return chosen / 2, recv.Interface().(core.Any), nil
+	}
+}
+
+// chan that closes after d
+func Timeout(d time.Duration) Chan {
+	c := Chan{&channel{ch: make(chan core.Any), done: make(chan struct{})}}
+	time.AfterFunc(d, c.Close)
+	return c
+}
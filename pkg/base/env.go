@@ -1,42 +1,123 @@
 package base
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 
 	"github.com/starlight/ocelot/pkg/core"
 )
 
+// safe for concurrent use by async futures
 type Env struct {
 	outer *Env
-	data  map[string]core.Any
+	data  *bindings
+	// cancels evaluation in this env
+	ctx context.Context
+	// sandbox limits, nil when unlimited
+	quota *quota
+	// nesting of the evaluation chain on this env's goroutine, for the
+	// depth quota
+	depth *int64
+	// modules imported into this env tree, shared from its root
+	modules *sync.Map
+	// actor of evaluation outside spawned ones, shared from the root
+	main *mainActor
 }
 
 func NewEnv(outer *Env) *Env {
-	data := make(map[string]core.Any)
-	return &Env{outer, data}
+	data := &bindings{vars: make(map[string]core.Any), gens: make(map[string]uint64)}
+	env := &Env{outer: outer, data: data}
+	if outer != nil {
+		env.ctx, env.quota, env.depth = outer.ctx, outer.quota, outer.depth
+		env.modules, env.main = outer.modules, outer.main
+	} else {
+		env.modules, env.main = &sync.Map{}, &mainActor{}
+	}
+	return env
+}
+
+// new env cancelled by ctx rather than the outer env's context
+func NewEnvContext(ctx context.Context, outer *Env) *Env {
+	env := NewEnv(outer)
+	env.ctx = ctx
+	return env
+}
+
+// scope of a call to a closure, cancelled and limited like the caller
+func NewCallEnv(caller *Env, closure *Env) *Env {
+	env := NewEnv(closure)
+	env.ctx, env.quota, env.depth = caller.ctx, caller.quota, caller.depth
+	return env
+}
+
+// same bindings, cancelled by ctx
+func (env *Env) WithContext(ctx context.Context) *Env {
+	return &Env{outer: env.outer, data: env.data, ctx: ctx, quota: env.quota, depth: env.depth, modules: env.modules, main: env.main}
+}
+
+// like WithContext, for evaluation on a new goroutine, its nesting
+// counted from zero
+func (env *Env) Task(ctx context.Context) *Env {
+	task := env.WithContext(ctx)
+	task.depth = new(int64)
+	return task
+}
+
+// outermost env, holding the builtins
+func (env *Env) Root() *Env {
+	for env.outer != nil {
+		env = env.outer
+	}
+	return env
+}
+
+// modules imported into this env tree, by path
+func (env *Env) Modules() *sync.Map {
+	return env.modules
+}
+
+func (env *Env) Context() context.Context {
+	if env.ctx == nil {
+		return context.Background()
+	}
+	return env.ctx
 }
 
 func (env *Env) Get(sym core.Symbol) (core.Any, error) {
 	scope, val := env.find(sym)
 	if scope == nil {
-		return core.Null{}, fmt.Errorf("%#v: unable to resolve symbol", sym)
+		return core.Null{}, unresolved(sym)
 	}
 	return val, nil
 }
 
+// error for sym at its source position
+func unresolved(sym core.Symbol) error {
+	return &PosError{Pos: sym.Pos, Name: sym.Val, Err: errors.New("unable to resolve symbol")}
+}
+
 func (env *Env) Set(sym core.Symbol, val core.Any) core.Any {
 	switch future := val.(type) {
 	default:
 		break
 	case Future:
-		// memoize futures
+		// memoize futures, resolved once however many goroutines ask
+		memo := future.Memo()
+		gen := atomic.AddUint64(&bindCount, 1)
 		rebind := func() (core.Any, error) {
-			val, err := future.Get()
-			return env.Set(sym, val), err
+			val, err := memo()
+			if err == nil {
+				// unless bound again meanwhile, errors stay memoized
+				env.data.setIf(sym.Val, gen, val)
+			}
+			return val, err
 		}
-		val = Future(rebind)
+		env.data.set(sym.Val, Future(rebind), gen)
+		return Future(rebind)
 	}
-	env.data[sym.Val] = val
+	env.data.set(sym.Val, val, 0)
 	return val
 }
 
@@ -44,26 +125,44 @@ func (env *Env) SetFunc(str string, fn Func) {
 	env.Set(core.NewSymbol(str, nil), fn)
 }
 
+// bind in env the names bound in scope itself, not its outer envs
+func (env *Env) Adopt(scope *Env) {
+	scope.data.RLock()
+	defer scope.data.RUnlock()
+	for name, val := range scope.data.vars {
+		env.data.set(name, val, scope.data.gens[name])
+	}
+}
+
 // cause a future binding to resolve async
 func (env *Env) Async(sym core.Symbol) error {
-	scope, val := env.find(sym)
+	scope, _ := env.find(sym)
 	if scope == nil {
-		return fmt.Errorf("%#v: unable to resolve symbol", sym)
+		return unresolved(sym)
 	}
-	switch future := val.(type) {
-	default:
-		break
-	case Future:
-		scope.data[sym.Val] = future.Async()
+	scope.data.Lock()
+	defer scope.data.Unlock()
+	// bound again since find, keeps its gen
+	if future, ok := scope.data.vars[sym.Val].(Future); ok {
+		scope.data.vars[sym.Val] = future.AsyncContext(env.Context()).Memo()
 	}
 	return nil
 }
 
 func (env *Env) find(sym core.Symbol) (*Env, core.Any) {
-	val, ok := env.data[sym.Val]
+	scope, val := env.lookup(sym)
+	if scope == nil {
+		// mod.name
+		return env.findQualified(sym)
+	}
+	return scope, val
+}
+
+func (env *Env) lookup(sym core.Symbol) (*Env, core.Any) {
+	val, ok := env.data.get(sym.Val)
 	if !ok {
 		if env.outer != nil {
-			return env.outer.find(sym)
+			return env.outer.lookup(sym)
 		}
 		return nil, core.Null{}
 	}
@@ -73,8 +172,50 @@ func (env *Env) find(sym core.Symbol) (*Env, core.Any) {
 func (env *Env) Del(sym core.Symbol) error {
 	scope, _ := env.find(sym)
 	if scope == nil {
-		return fmt.Errorf("%#v: unable to resolve symbol", sym)
+		return unresolved(sym)
 	}
-	delete(scope.data, sym.Val)
+	scope.data.del(sym.Val)
 	return nil
 }
+
+// names bound in one scope, shared with WithContext copies of its env
+type bindings struct {
+	sync.RWMutex
+	vars map[string]core.Any
+	// which future each name was bound to, 0 for a value
+	gens map[string]uint64
+}
+
+// numbers futures bound by Set
+var bindCount uint64
+
+func (data *bindings) get(name string) (core.Any, bool) {
+	data.RLock()
+	defer data.RUnlock()
+	val, ok := data.vars[name]
+	return val, ok
+}
+
+func (data *bindings) set(name string, val core.Any, gen uint64) {
+	data.Lock()
+	defer data.Unlock()
+	data.vars[name] = val
+	data.gens[name] = gen
+}
+
+// replace the future bound at gen with its value, unless bound again
+func (data *bindings) setIf(name string, gen uint64, val core.Any) {
+	data.Lock()
+	defer data.Unlock()
+	if data.gens[name] == gen {
+		data.vars[name] = val
+		data.gens[name] = 0
+	}
+}
+
+func (data *bindings) del(name string) {
+	data.Lock()
+	defer data.Unlock()
+	delete(data.vars, name)
+	delete(data.gens, name)
+}
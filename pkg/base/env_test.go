@@ -0,0 +1,116 @@
+package base
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// resolve the binding of sym, as evaluation would
+func resolve(env *Env, sym core.Symbol) (core.Any, error) {
+	val, err := env.Get(sym)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if future, ok := val.(Future); ok {
+		return future.Get()
+	}
+	return val, nil
+}
+
+// run fn on n goroutines at once
+func parallel(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestSetResolvesFutureOnce(t *testing.T) {
+	env := NewEnv(nil)
+	sym := core.NewSymbol("x", nil)
+	var calls int64
+	env.Set(sym, Future(func() (core.Any, error) {
+		atomic.AddInt64(&calls, 1)
+		return core.String{Val: "x"}, nil
+	}))
+	parallel(50, func(i int) {
+		val, err := resolve(env, sym)
+		if err != nil || !val.Equal(core.String{Val: "x"}) {
+			t.Errorf("got %v, %v", val, err)
+		}
+	})
+	if calls != 1 {
+		t.Errorf("resolved %d times", calls)
+	}
+}
+
+func TestSetKeepsError(t *testing.T) {
+	env := NewEnv(nil)
+	sym := core.NewSymbol("x", nil)
+	boom := errors.New("boom")
+	env.Set(sym, Future(func() (core.Any, error) {
+		return core.Null{}, boom
+	}))
+	parallel(20, func(i int) {
+		if _, err := resolve(env, sym); !errors.Is(err, boom) {
+			t.Errorf("got %v, wanted boom", err)
+		}
+	})
+	if _, err := resolve(env, sym); !errors.Is(err, boom) {
+		t.Errorf("got %v after failing, wanted boom", err)
+	}
+}
+
+func TestSetKeepsNewerBinding(t *testing.T) {
+	env := NewEnv(nil)
+	sym := core.NewSymbol("x", nil)
+	release := make(chan struct{})
+	env.Set(sym, Future(func() (core.Any, error) {
+		<-release
+		return core.String{Val: "old"}, nil
+	}))
+	if err := env.Async(sym); err != nil {
+		t.Fatal(err)
+	}
+	val, _ := env.Get(sym)
+	env.Set(sym, core.String{Val: "new"})
+	close(release)
+	if _, err := val.(Future).Get(); err != nil {
+		t.Fatal(err)
+	}
+	if val, err := resolve(env, sym); err != nil || !val.Equal(core.String{Val: "new"}) {
+		t.Errorf("got %v, %v, wanted new", val, err)
+	}
+}
+
+func TestConcurrentBindings(t *testing.T) {
+	env := NewEnv(nil)
+	syms := []core.Symbol{core.NewSymbol("a", nil), core.NewSymbol("b", nil)}
+	parallel(100, func(i int) {
+		sym := syms[i%len(syms)]
+		local := NewEnv(env)
+		switch i % 4 {
+		case 0:
+			env.Set(sym, core.String{Val: "v"})
+		case 1:
+			env.Set(sym, Future(func() (core.Any, error) {
+				return core.String{Val: "v"}, nil
+			}))
+		case 2:
+			env.Async(sym)
+		case 3:
+			local.Set(sym, core.Null{})
+		}
+		// an error when not bound yet, only races matter here
+		resolve(local, sym)
+	})
+}
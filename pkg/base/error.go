@@ -0,0 +1,168 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// error raised at a source position, by a call or an unresolved symbol
+type PosError struct {
+	Pos  *core.Position
+	Name string
+	Err  error
+}
+
+func (e *PosError) Error() string {
+	if e.Pos == nil {
+		return fmt.Sprintf("%s: %s", e.Name, e.Err)
+	}
+	msg := fmt.Sprintf("%v: %s: %s", e.Pos, e.Name, e.Err)
+	// excerpt source at the innermost call only
+	var inner *PosError
+	if !errors.As(e.Err, &inner) {
+		if excerpt := e.Pos.Excerpt(); excerpt != "" {
+			msg += "\n" + excerpt
+		}
+	}
+	return msg
+}
+
+func (e *PosError) Unwrap() error {
+	return e.Err
+}
+
+// wrap err with the position of call ast, pos if known
+func traceError(ast core.Expr, pos *core.Position, err error) error {
+	switch err.(type) {
+	case *CancelError, *QuotaError:
+		// unwinds as is, however deep the stack
+		return err
+	}
+	if pos == nil {
+		pos = core.PosOf(ast[0])
+	}
+	name := fmt.Sprintf("%#v", ast[0])
+	if sym, ok := ast[0].(core.Symbol); ok {
+		name = sym.Val
+	}
+	var thrown *EvalError
+	if errors.As(err, &thrown) {
+		// copied, the original may be shared by a memoized future or promise
+		stack := make([]Frame, len(thrown.Stack), len(thrown.Stack)+1)
+		copy(stack, thrown.Stack)
+		thrown = &EvalError{Value: thrown.Value, Stack: append(stack, Frame{Name: name, Pos: pos})}
+		err = &stackError{Err: err, thrown: thrown}
+	}
+	return &PosError{Pos: pos, Name: name, Err: err}
+}
+
+// err with the thrown value's stack grown by one frame
+type stackError struct {
+	Err    error
+	thrown *EvalError
+}
+
+func (e *stackError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *stackError) Unwrap() error {
+	return e.Err
+}
+
+// errors.As finds the copy rather than the original
+func (e *stackError) As(target interface{}) bool {
+	if thrown, ok := target.(**EvalError); ok {
+		*thrown = e.thrown
+		return true
+	}
+	return false
+}
+
+// evaluation stopped by its context, not raised by the script
+type CancelError struct {
+	// context.Canceled or context.DeadlineExceeded
+	Err error
+}
+
+func (e *CancelError) Error() string {
+	return "evaluation cancelled: " + e.Err.Error()
+}
+
+func (e *CancelError) Unwrap() error {
+	return e.Err
+}
+
+// CancelError once ctx is done, else nil
+func CheckContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return &CancelError{Err: err}
+	}
+	return nil
+}
+
+// err stopped evaluation by cancellation or deadline
+func Cancelled(err error) bool {
+	var cancel *CancelError
+	return errors.As(err, &cancel)
+}
+
+// err stopped evaluation by cancellation or a quota, rather than by the script
+func Stopped(err error) bool {
+	var quota *QuotaError
+	return Cancelled(err) || errors.As(err, &quota)
+}
+
+// go panic recovered during evaluation
+type PanicError struct {
+	Value interface{}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// call stack entry
+type Frame struct {
+	Name string
+	Pos  *core.Position
+}
+
+func (frame Frame) String() string {
+	if frame.Pos == nil {
+		return frame.Name
+	}
+	return fmt.Sprintf("%s (%v)", frame.Name, frame.Pos)
+}
+
+// value thrown by a script, with the call stack it unwound (innermost first)
+type EvalError struct {
+	Value core.Any
+	Stack []Frame
+}
+
+func NewEvalError(val core.Any) *EvalError {
+	return &EvalError{Value: val}
+}
+
+func (e *EvalError) Error() string {
+	return e.Value.String()
+}
+
+// errors.Is matches thrown values that are equal
+func (e *EvalError) Is(target error) bool {
+	other, ok := target.(*EvalError)
+	return ok && e.Value.Equal(other.Value)
+}
+
+// value of a thrown error, or the message of any other error
+func ErrorValue(err error) core.Any {
+	var thrown *EvalError
+	if errors.As(err, &thrown) {
+		return thrown.Value
+	}
+	return core.String{Val: err.Error()}
+}
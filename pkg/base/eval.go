@@ -1,36 +1,66 @@
 package base
 
 import (
+	"context"
 	"errors"
 
 	"github.com/starlight/ocelot/internal/parser"
 	"github.com/starlight/ocelot/pkg/core"
 )
 
+func EvalFileContext(ctx context.Context, filename string, env *Env) (core.Any, error) {
+	if env == nil {
+		return core.Null{}, errors.New("evaluation with nil env")
+	}
+	return EvalFile(filename, env.WithContext(ctx))
+}
+
+func EvalStrContext(ctx context.Context, in string, env *Env) (core.Any, error) {
+	if env == nil {
+		return core.Null{}, errors.New("evaluation with nil env")
+	}
+	return EvalStr(in, env.WithContext(ctx))
+}
+
+// eager eval, stopped with a CancelError once ctx is done
+func EvalContext(ctx context.Context, ast core.Any, env *Env) (core.Any, error) {
+	if env == nil {
+		return core.Null{}, errors.New("evaluation with nil env")
+	}
+	return Eval(ast, env.WithContext(ctx))
+}
+
 func EvalFile(filename string, env *Env) (core.Any, error) {
 	if env == nil {
 		return core.Null{}, errors.New("evaluation with nil env")
 	}
-	ast, err := parser.ParseFile(filename)
+	ast, err := parser.ParseSourceFile(filename)
 	if err != nil {
 		return core.Null{}, err
 	}
-	return Eval(ast.(core.Any), env)
+	return Eval(ast, env)
 }
 
 func EvalStr(in string, env *Env) (core.Any, error) {
 	if env == nil {
 		return core.Null{}, errors.New("evaluation with nil env")
 	}
-	ast, err := parser.Parse("parse", []byte(in))
+	ast, err := parser.ParseSource("input", []byte(in))
 	if err != nil {
 		return core.Null{}, err
 	}
-	return Eval(ast.(core.Any), env)
+	return Eval(ast, env)
 }
 
 // eager eval
 func Eval(ast core.Any, env *Env) (val core.Any, err error) {
+	if env.quota != nil {
+		// nesting grows the go stack
+		if err = env.quota.enter(env.depth); err != nil {
+			return core.Null{}, err
+		}
+		defer env.quota.leave(env.depth)
+	}
 	val, err = evalAst(ast, env)
 	if err != nil {
 		return
@@ -39,7 +69,7 @@ func Eval(ast core.Any, env *Env) (val core.Any, err error) {
 	default:
 		return
 	case Future:
-		return future.Get()
+		return future.GetContext(env.Context())
 	}
 }
 
@@ -56,10 +86,16 @@ func evalAst(ast core.Any, env *Env) (core.Any, error) {
 	default:
 		// String, Number, Bool, Null
 		return any, nil
+	case core.Node:
+		// parsed call, traced at its position
+		if expr, ok := any.Val.(core.Expr); ok {
+			return evalExpr(expr, any.Pos, env)
+		}
+		return evalAst(any.Val, env)
 	case core.Symbol:
 		return env.Get(any)
 	case core.Expr:
-		return evalExpr(any, env)
+		return evalExpr(any, nil, env)
 	case core.Vector:
 		return evalVector(any, env)
 	case core.Hash:
@@ -67,12 +103,16 @@ func evalAst(ast core.Any, env *Env) (core.Any, error) {
 	}
 }
 
-// (eval s-expressions)
-func evalExpr(ast core.Expr, env *Env) (core.Any, error) {
+// (eval s-expressions) at pos, if parsed
+func evalExpr(ast core.Expr, pos *core.Position, env *Env) (core.Any, error) {
 	// () == nil
 	if len(ast) == 0 {
 		return core.Null{}, nil
 	}
+	// stop on shutdown, cancel or quota, also between tail-calls
+	if err := env.step(); err != nil {
+		return core.Null{}, err
+	}
 	// eval first item
 	val, err := Eval(ast[0], env)
 	if err != nil {
@@ -82,9 +122,16 @@ func evalExpr(ast core.Expr, env *Env) (core.Any, error) {
 	switch fn := val.(type) {
 	default:
 		break
+	case Macro:
+		// expand, then eval expansion (tail-call)
+		expansion, err := fn.Expand(ast, env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		return FutureEval(expansion, env).Trace(ast, pos), nil
 	case Func:
 		// function
-		return fn.Future(ast, env), nil
+		return fn.call(ast, pos, env), nil
 	}
 	// vector
 	first := core.Vector{val}
@@ -100,6 +147,12 @@ func evalExpr(ast core.Expr, env *Env) (core.Any, error) {
 
 // [eval vectors]
 func evalVector(ast core.Vector, env *Env) (core.Any, error) {
+	if err := env.Check(); err != nil {
+		return core.Null{}, err
+	}
+	if err := env.Alloc(len(ast)); err != nil {
+		return core.Null{}, err
+	}
 	res := make(core.Vector, len(ast))
 	for i, item := range ast {
 		val, err := Eval(item, env)
@@ -113,6 +166,12 @@ func evalVector(ast core.Vector, env *Env) (core.Any, error) {
 
 // {:eval maps}
 func evalHash(ast core.Hash, env *Env) (core.Any, error) {
+	if err := env.Check(); err != nil {
+		return core.Null{}, err
+	}
+	if err := env.Alloc(len(ast)); err != nil {
+		return core.Null{}, err
+	}
 	res := make(core.Hash, len(ast))
 	for key, item := range ast {
 		val, err := Eval(item, env)
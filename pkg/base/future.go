@@ -1,13 +1,19 @@
 package base
 
 import (
-	"fmt"
+	"context"
+	"sync"
 
 	"github.com/starlight/ocelot/pkg/core"
 )
 
 // trampoline to resolve future values
 func (future Future) Get() (val core.Any, err error) {
+	return future.GetContext(context.Background())
+}
+
+// like Get, but stop between bounces once ctx is done
+func (future Future) GetContext(ctx context.Context) (val core.Any, err error) {
 	val, err = future()
 	for {
 		if err != nil {
@@ -17,6 +23,9 @@ func (future Future) Get() (val core.Any, err error) {
 		default:
 			return
 		case Future:
+			if err = CheckContext(ctx); err != nil {
+				return core.Null{}, err
+			}
 			val, err = future()
 		}
 	}
@@ -24,10 +33,16 @@ func (future Future) Get() (val core.Any, err error) {
 
 // resolve future asynchronously and return new future
 func (future Future) Async() Future {
+	return future.AsyncContext(context.Background())
+}
+
+// like Async, but stop waiting when ctx is done
+func (future Future) AsyncContext(ctx context.Context) Future {
 	tunnel := make(chan Future, 1)
 	// resolve
 	send := func() {
-		val, err := future.Get()
+		// a panic must not take down the process from a goroutine
+		val, err := Future(future.Get).Recover()()
 		tunnel <- func() (core.Any, error) {
 			return val, err
 		}
@@ -35,26 +50,61 @@ func (future Future) Async() Future {
 	go send()
 	// await future
 	recv := func() (core.Any, error) {
-		return <-tunnel, nil
+		select {
+		case future := <-tunnel:
+			return future, nil
+		case <-ctx.Done():
+			return core.Null{}, CheckContext(ctx)
+		}
 	}
 	return recv
 }
 
-// trace errors mapped to source ast
-func (future Future) Trace(ast core.Expr) Future {
+// trace errors mapped to source ast at pos, or at its head if nil
+func (future Future) Trace(ast core.Expr, pos *core.Position) Future {
 	return func() (val core.Any, err error) {
 		val, err = future.Get()
 		if err != nil {
-			err = fmt.Errorf("%#v: %s", ast[0], err)
+			err = traceError(ast, pos, err)
 		}
 		return
 	}
 }
 
-// lazy function call
+// lazy function call, a panic in fn is an error at ast
 func (fn Func) Future(ast core.Expr, env *Env) Future {
+	return fn.call(ast, nil, env)
+}
+
+// lazy function call of ast parsed at pos
+func (fn Func) call(ast core.Expr, pos *core.Position, env *Env) Future {
 	future := func() (core.Any, error) {
 		return fn(ast, env)
 	}
-	return Future(future).Trace(ast)
+	return Future(future).Recover().Trace(ast, pos)
+}
+
+// turn a panic while resolving into a PanicError
+func (future Future) Recover() Future {
+	return func() (val core.Any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				val, err = core.Null{}, &PanicError{Value: r}
+			}
+		}()
+		return future()
+	}
+}
+
+// resolve future at most once, sharing the result
+func (future Future) Memo() Future {
+	var once sync.Once
+	var val core.Any
+	var err error
+	return func() (core.Any, error) {
+		once.Do(func() {
+			val, err = future.Get()
+		})
+		return val, err
+	}
 }
@@ -0,0 +1,48 @@
+package base
+
+import (
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// expand macro call once, passing args unevaluated
+func (macro Macro) Expand(ast core.Expr, env *Env) (core.Any, error) {
+	args := make(core.Expr, len(ast))
+	args[0] = ast[0]
+	for i, item := range ast[1:] {
+		args[i+1] = core.Expr{core.NewSymbol("quote", nil), item}
+	}
+	return Func(macro).Future(args, env).Get()
+}
+
+// expand until ast is no longer a macro call
+func MacroExpand(ast core.Any, env *Env) (core.Any, error) {
+	for {
+		expr, macro, ok := macroCall(ast, env)
+		if !ok {
+			return ast, nil
+		}
+		val, err := macro.Expand(expr, env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		ast = val
+	}
+}
+
+// is ast an s-expression with a symbol bound to a macro at the head
+func macroCall(ast core.Any, env *Env) (core.Expr, Macro, bool) {
+	expr, ok := core.Unwrap(ast).(core.Expr)
+	if !ok || len(expr) == 0 {
+		return nil, nil, false
+	}
+	sym, ok := expr[0].(core.Symbol)
+	if !ok {
+		return nil, nil, false
+	}
+	scope, val := env.find(sym)
+	if scope == nil {
+		return nil, nil, false
+	}
+	macro, ok := val.(Macro)
+	return expr, macro, ok
+}
@@ -0,0 +1,53 @@
+package base
+
+import (
+	"strings"
+
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// type:module
+type Module struct {
+	Name string
+	Path string
+	Env  *Env
+}
+
+func (mod Module) String() string {
+	return "&module"
+}
+
+func (mod Module) GoString() string {
+	return "&module<" + mod.Path + ">"
+}
+
+func (mod Module) Equal(any core.Any) bool {
+	switch arg := any.(type) {
+	default:
+		return false
+	case Module:
+		return mod.Env == arg.Env
+	}
+}
+
+// resolve mod.name, looking only at definitions made in the module
+func (env *Env) findQualified(sym core.Symbol) (*Env, core.Any) {
+	dot := strings.IndexByte(sym.Val, '.')
+	if dot < 0 {
+		return nil, core.Null{}
+	}
+	prefix := core.NewSymbol(sym.Val[:dot], sym.Pos)
+	scope, val := env.find(prefix)
+	if scope == nil {
+		return nil, core.Null{}
+	}
+	mod, ok := val.(Module)
+	if !ok {
+		return nil, core.Null{}
+	}
+	name := core.NewSymbol(sym.Val[dot+1:], sym.Pos)
+	if val, ok := mod.Env.data.get(name.Val); ok {
+		return mod.Env, val
+	}
+	return mod.Env.findQualified(name)
+}
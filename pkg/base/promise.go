@@ -0,0 +1,148 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// type:promise
+type Promise struct {
+	*promise
+}
+
+type promise struct {
+	// closed once val and err are set
+	done   chan struct{}
+	val    core.Any
+	err    error
+	cancel context.CancelFunc
+}
+
+// awaiting a promise stopped by Cancel
+var ErrPromiseCancelled = errors.New("promise cancelled")
+
+// eval ast in a goroutine, cancelled with env or by Cancel
+func NewPromise(ast core.Any, env *Env) Promise {
+	ctx, cancel := context.WithCancel(env.Context())
+	p := Promise{&promise{done: make(chan struct{}), cancel: cancel}}
+	eval := func() (core.Any, error) {
+		return Eval(ast, env.Task(ctx))
+	}
+	go func() {
+		defer cancel()
+		// a panic must not take down the process from a goroutine
+		val, err := Future(eval).Recover()()
+		if Cancelled(err) && env.Context().Err() == nil {
+			// by Cancel, not by the caller
+			err = ErrPromiseCancelled
+		}
+		p.val, p.err = val, err
+		close(p.done)
+	}()
+	return p
+}
+
+func (p Promise) String() string {
+	select {
+	case <-p.done:
+		if p.err != nil {
+			return "#<promise failed>"
+		}
+		return fmt.Sprintf("#<promise %#v>", p.val)
+	default:
+		return "#<promise pending>"
+	}
+}
+
+func (p Promise) GoString() string {
+	return p.String()
+}
+
+func (p Promise) Equal(any core.Any) bool {
+	switch arg := any.(type) {
+	default:
+		return false
+	case Promise:
+		return p.promise == arg.promise
+	}
+}
+
+// stop evaluation, a no-op once settled
+func (p Promise) Cancel() {
+	p.cancel()
+}
+
+// value once settled, or its error
+func (p Promise) Await(ctx context.Context) (core.Any, error) {
+	select {
+	case <-p.done:
+		return p.val, p.err
+	case <-ctx.Done():
+		return core.Null{}, CheckContext(ctx)
+	}
+}
+
+// values in order, failing with the first promise to fail and
+// cancelling the rest
+func AwaitAll(ctx context.Context, ps []Promise) ([]core.Any, error) {
+	cases := settleCases(ctx, ps)
+	for range ps {
+		i, err := settled(ctx, cases)
+		if err == nil {
+			err = ps[i].err
+		}
+		if err != nil {
+			cancelAll(ps)
+			return nil, err
+		}
+		// settled ones never fire again
+		cases[i].Chan = reflect.ValueOf((chan struct{})(nil))
+	}
+	res := make([]core.Any, len(ps))
+	for i, p := range ps {
+		res[i] = p.val
+	}
+	return res, nil
+}
+
+// value or error of the first promise to settle, cancelling the rest
+func Race(ctx context.Context, ps []Promise) (core.Any, error) {
+	if len(ps) == 0 {
+		return core.Null{}, errors.New("race without promises")
+	}
+	i, err := settled(ctx, settleCases(ctx, ps))
+	cancelAll(ps)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return ps[i].val, ps[i].err
+}
+
+// done of each promise, then ctx
+func settleCases(ctx context.Context, ps []Promise) []reflect.SelectCase {
+	cases := make([]reflect.SelectCase, len(ps)+1)
+	for i, p := range ps {
+		cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(p.done)}
+	}
+	cases[len(ps)] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+	return cases
+}
+
+// index of the next promise to settle
+func settled(ctx context.Context, cases []reflect.SelectCase) (int, error) {
+	chosen, _, _ := reflect.Select(cases)
+	if chosen == len(cases)-1 {
+		return -1, CheckContext(ctx)
+	}
+	return chosen, nil
+}
+
+func cancelAll(ps []Promise) {
+	for _, p := range ps {
+		p.Cancel()
+	}
+}
@@ -0,0 +1,121 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// limits of a sandbox env, zero is unlimited
+type Limits struct {
+	// evaluated calls, including tail-calls
+	Steps int64
+	// vector and hash items created, approximately
+	Alloc int64
+	// nested eager evaluations in one chain, each async task starts its own
+	Depth int64
+	// wall clock from creation of the sandbox
+	Timeout time.Duration
+}
+
+// evaluation stopped by a sandbox limit
+type QuotaError struct {
+	// steps, alloc, depth or time
+	Quota string
+	Limit interface{}
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("quota exceeded: %s limit %v", e.Quota, e.Limit)
+}
+
+// usage shared by every env of a sandbox
+type quota struct {
+	limits   Limits
+	deadline time.Time
+	steps    int64
+	alloc    int64
+}
+
+// root env limited by limits and cancelled by ctx, release stops its clock
+func NewSandbox(ctx context.Context, limits Limits) (*Env, context.CancelFunc) {
+	q := &quota{limits: limits}
+	release := context.CancelFunc(func() {})
+	if limits.Timeout > 0 {
+		q.deadline = time.Now().Add(limits.Timeout)
+		ctx, release = context.WithDeadline(ctx, q.deadline)
+	}
+	env := NewEnvContext(ctx, nil)
+	env.quota, env.depth = q, new(int64)
+	return env, release
+}
+
+// one step of evaluation
+func (q *quota) step() error {
+	if max := q.limits.Steps; max > 0 && atomic.AddInt64(&q.steps, 1) > max {
+		return &QuotaError{Quota: "steps", Limit: max}
+	}
+	return q.clock()
+}
+
+func (q *quota) clock() error {
+	if !q.deadline.IsZero() && !time.Now().Before(q.deadline) {
+		return &QuotaError{Quota: "time", Limit: q.limits.Timeout}
+	}
+	return nil
+}
+
+func (q *quota) allocate(n int) error {
+	if n < 0 {
+		// would refund the budget
+		return fmt.Errorf("negative allocation %d", n)
+	}
+	if max := q.limits.Alloc; max > 0 && atomic.AddInt64(&q.alloc, int64(n)) > max {
+		return &QuotaError{Quota: "alloc", Limit: max}
+	}
+	return nil
+}
+
+// one level deeper in the evaluation chain counting depth
+func (q *quota) enter(depth *int64) error {
+	if max := q.limits.Depth; max > 0 && atomic.AddInt64(depth, 1) > max {
+		atomic.AddInt64(depth, -1)
+		return &QuotaError{Quota: "depth", Limit: max}
+	}
+	return nil
+}
+
+func (q *quota) leave(depth *int64) {
+	if q.limits.Depth > 0 {
+		atomic.AddInt64(depth, -1)
+	}
+}
+
+// stop on cancel, or once the sandbox clock runs out
+func (env *Env) Check() error {
+	if env.quota != nil {
+		if err := env.quota.clock(); err != nil {
+			return err
+		}
+	}
+	return CheckContext(env.Context())
+}
+
+// count n vector or hash items against the sandbox
+func (env *Env) Alloc(n int) error {
+	if env.quota == nil {
+		return nil
+	}
+	return env.quota.allocate(n)
+}
+
+// count a step against the sandbox, then Check
+func (env *Env) step() error {
+	if env.quota != nil {
+		if err := env.quota.step(); err != nil {
+			return err
+		}
+	}
+	return CheckContext(env.Context())
+}
@@ -29,14 +29,30 @@ func (fn Func) Equal(any core.Any) bool {
 // type:future
 type Future func() (core.Any, error)
 
+// not yet resolved, like a pending promise
 func (future Future) String() string {
-	return "?← " // should not happen
+	return "#<promise pending>"
 }
 
 func (future Future) GoString() string {
-	return "?← " // should not happen
+	return future.String()
 }
 
 func (future Future) Equal(any core.Any) bool {
 	return false // not comparable
 }
+
+// type:macro
+type Macro Func
+
+func (macro Macro) String() string {
+	return "&macro"
+}
+
+func (macro Macro) GoString() string {
+	return macro.String()
+}
+
+func (macro Macro) Equal(any core.Any) bool {
+	return false // not comparable
+}
@@ -0,0 +1,202 @@
+package builtin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// (spawn f args...) actor running (f args...) in its own env
+func _spawn(ast core.Expr, env *base.Env) (core.Any, error) {
+	return spawn(ast, env, false)
+}
+
+// (spawn-link f args...) spawn linked to the caller
+func _spawnLink(ast core.Expr, env *base.Env) (core.Any, error) {
+	return spawn(ast, env, true)
+}
+
+func _actorQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	val, err := oneArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	_, ok := val.(base.Actor)
+	return core.Bool(ok), nil
+}
+
+// (self) actor evaluating the call
+func _self(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 1); err != nil {
+		return core.Null{}, err
+	}
+	return env.Self(), nil
+}
+
+// (send a msg) msg, dropped once a has exited
+func _send(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	a, err := evalActor(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	msg, err := base.Eval(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if _, err := a.Send(msg); err != nil {
+		return core.Null{}, err
+	}
+	return msg, nil
+}
+
+// (receive pattern body ...) or (receive pattern body ... :after t body)
+// takes the oldest message matching a pattern, leaving the others queued;
+// without one after seconds or a duration, the :after body instead
+func _receive(ast core.Expr, env *base.Env) (core.Any, error) {
+	clauses := ast[1:]
+	var after core.Any
+	var timeout <-chan time.Time
+	if n := len(clauses); n >= 3 {
+		if key, ok := core.Unwrap(clauses[n-3]).(core.Keyword); ok && key.Val == "after" {
+			dur, err := evalDuration(clauses[n-2], env)
+			if err != nil {
+				return core.Null{}, err
+			}
+			timer := time.NewTimer(dur)
+			defer timer.Stop()
+			timeout, after = timer.C, clauses[n-1]
+			clauses = clauses[:n-3]
+		}
+	}
+	if len(clauses) == 0 && after == nil {
+		return core.Null{}, fmt.Errorf("wanted a pattern and body")
+	}
+	if len(clauses)%2 != 0 {
+		return core.Null{}, fmt.Errorf("pattern missing body")
+	}
+	var local *base.Env
+	var body core.Any
+	match := func(msg core.Any) (bool, error) {
+		for i := 0; i < len(clauses); i += 2 {
+			scope := base.NewEnv(env)
+			ok, err := matchPattern(clauses[i], msg, scope)
+			if err != nil || ok {
+				local, body = scope, clauses[i+1]
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+	_, ok, err := env.Self().Receive(env.Context(), match, timeout)
+	if err != nil {
+		return core.Null{}, waitError(err, env)
+	}
+	if !ok {
+		return base.FutureEval(after, env), nil
+	}
+	return base.FutureEval(body, local), nil
+}
+
+// (link a) the caller and a are sent [:exit actor reason] when the other
+// crashes, so a supervisor can restart it
+func _link(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	a, err := evalActor(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	env.Self().Link(a)
+	return a, nil
+}
+
+// (monitor a) the caller is sent [:down a reason] when a exits
+func _monitor(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	a, err := evalActor(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	env.Self().Monitor(a)
+	return a, nil
+}
+
+// (kill a) stop a, which exits with :killed
+func _kill(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	a, err := evalActor(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	a.Kill()
+	return core.Null{}, nil
+}
+
+func _aliveQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	a, err := evalActor(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	_, exited := a.Reason()
+	return core.Bool(!exited), nil
+}
+
+// start (f args...) in a goroutine with a child env, exiting :normal when
+// it returns, :killed, or with the value of its error
+func spawn(ast core.Expr, env *base.Env, link bool) (core.Any, error) {
+	if err := minLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	fn, err := evalFunc(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	args, err := evalArgs(ast[2:], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	a, ctx := base.NewActor(env.Context(), env)
+	if link {
+		env.Self().Link(a)
+	}
+	local := base.NewEnv(env.Task(ctx))
+	go func() {
+		// a panic must not take down the process from a goroutine
+		_, err := base.Future(func() (core.Any, error) {
+			return call(fn, ast[1], local, args...).GetContext(ctx)
+		}).Recover()()
+		var reason core.Any = core.Keyword{Val: "normal"}
+		if a.Killed() {
+			reason = core.Keyword{Val: "killed"}
+		} else if err != nil {
+			reason = base.ErrorValue(err)
+		}
+		a.Exit(reason)
+	}()
+	return a, nil
+}
+
+func evalActor(ast core.Any, env *base.Env) (base.Actor, error) {
+	val, err := base.Eval(ast, env)
+	if err != nil {
+		return base.Actor{}, err
+	}
+	a, ok := val.(base.Actor)
+	if !ok {
+		return base.Actor{}, fmt.Errorf("called with non-actor %#v", val)
+	}
+	return a, nil
+}
@@ -0,0 +1,137 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+func testEnv(t *testing.T, in string) *base.Env {
+	t.Helper()
+	env, err := BuiltinEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := base.EvalStr(in, env); err != nil {
+		t.Fatalf("%s: %v", in, err)
+	}
+	return env
+}
+
+// value of the last form in, EvalStr gives one for each
+func evalLast(in string, env *base.Env) (core.Any, error) {
+	val, err := base.EvalStr(in, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	vals := val.(core.Vector)
+	return vals[len(vals)-1], nil
+}
+
+// run fn on n goroutines at once
+func parallelTest(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestConcurrentDefAsyncAwait(t *testing.T) {
+	env := testEnv(t, `(defn! slow [x] (do (wait 0.001) (mul x x)))`)
+	parallelTest(20, func(i int) {
+		in := fmt.Sprintf(`
+			(def! x%[1]d (slow %[1]d))
+			(async x%[1]d)
+			(def! shared %[1]d)
+			(await-all [(async x%[1]d) (async (slow shared)) (async x%[1]d)])`, i)
+		val, err := evalLast(in, env)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		res, ok := val.(core.Vector)
+		if !ok || len(res) != 3 || !res[0].Equal(res[2]) || res[0].String() != fmt.Sprint(i*i) {
+			t.Errorf("x%d: got %v", i, val)
+		}
+	})
+}
+
+func TestConcurrentAwaitFailedPromise(t *testing.T) {
+	env := testEnv(t, `
+		(defn! boom [] (throw :boom))
+		(def! p (async (boom)))
+		(defn! await-p [] (await p))`)
+	parallelTest(20, func(i int) {
+		_, err := base.EvalStr(`(await-p)`, env)
+		var thrown *base.EvalError
+		if !errors.As(err, &thrown) || !thrown.Value.Equal(core.Keyword{Val: "boom"}) {
+			t.Errorf("got %v, wanted :boom", err)
+			return
+		}
+		// boom, throw, await and await-p, however often p is awaited
+		if len(thrown.Stack) != 4 {
+			t.Errorf("got stack %v", thrown.Stack)
+		}
+	})
+}
+
+func TestLazyBindingError(t *testing.T) {
+	env := testEnv(t, `
+		(def! lazy (throw :boom))
+		(try lazy (catch [e] e))`)
+	parallelTest(20, func(i int) {
+		if _, err := base.EvalStr(`lazy`, env); err == nil {
+			t.Errorf("lazy resolved without its error")
+		}
+	})
+}
+
+func TestAsyncKeepsNewerDef(t *testing.T) {
+	env := testEnv(t, `
+		(defn! slow [x] (do (wait 0.02) x))
+		(def! v (slow 1))
+		(async v)
+		(def! v 99)
+		(wait 0.05)`)
+	val, err := evalLast(`v`, env)
+	if err != nil || val.String() != "99" {
+		t.Errorf("got %v, %v, wanted 99", val, err)
+	}
+}
+
+func TestWithTimeoutLazyResult(t *testing.T) {
+	env := testEnv(t, `
+		(defn! inc [x] (add x 1))
+		(def! s (with-timeout 1 (map inc (range))))`)
+	val, err := evalLast(`(take 3 s)`, env)
+	if err != nil || val.String() != "(1 2 3)" {
+		t.Errorf("got %v, %v, wanted (1 2 3)", val, err)
+	}
+	if _, err := evalLast(`(with-timeout 0.01 (wait 1))`, env); err == nil {
+		t.Errorf("with-timeout did not time out")
+	}
+}
+
+func TestWithTimeoutPromiseCancelledByCaller(t *testing.T) {
+	env := testEnv(t, ``)
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := base.EvalStrContext(ctx, `(def! p (with-timeout 5 (async (wait 10))))`, env); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	start := time.Now()
+	if _, err := evalLast(`(with-timeout 2 (await p))`, env); err == nil || time.Since(start) > time.Second {
+		t.Errorf("got %v after %v, wanted the promise cancelled with its caller", err, time.Since(start))
+	}
+}
@@ -0,0 +1,236 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// (atom val) or (atom val :validator f)
+func _atom(ast core.Expr, env *base.Env) (core.Any, error) {
+	if len(ast) != 2 && len(ast) != 4 {
+		return core.Null{}, fmt.Errorf("wanted 1 or 3 args, got %d", len(ast)-1)
+	}
+	val, err := base.Eval(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	a := base.NewAtom(val)
+	if len(ast) == 4 {
+		if key, ok := core.Unwrap(ast[2]).(core.Keyword); !ok || key.Val != "validator" {
+			return core.Null{}, fmt.Errorf("wanted :validator, got %#v", ast[2])
+		}
+		fn, err := evalFunc(ast[3], env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		a.SetValidator(fn)
+		if err := validate(a, ast[3], val, env); err != nil {
+			return core.Null{}, err
+		}
+	}
+	return a, nil
+}
+
+func _atomQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	val, err := oneArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	_, ok := val.(base.Atom)
+	return core.Bool(ok), nil
+}
+
+// (deref a) value of an atom, or awaits a promise
+func _deref(ast core.Expr, env *base.Env) (core.Any, error) {
+	val, err := oneArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	switch arg := val.(type) {
+	default:
+		return core.Null{}, fmt.Errorf("called with non-atom %#v", val)
+	case base.Atom:
+		res, _ := arg.Deref()
+		return res, nil
+	case base.Promise:
+		res, err := arg.Await(env.Context())
+		return res, waitError(err, env)
+	}
+}
+
+// (reset! a val) val
+func _resetE(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	a, err := evalAtom(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return update(a, ast, env, func(old core.Any) (core.Any, bool, error) {
+		return val, true, nil
+	})
+}
+
+// (swap! a f args...) sets (f old args...), calling f again when another
+// change got there first, so f should be free of side effects
+func _swapE(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := minLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	a, err := evalAtom(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	fn, err := evalFunc(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	args, err := evalArgs(ast[3:], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return update(a, ast, env, func(old core.Any) (core.Any, bool, error) {
+		val, err := call(fn, ast[2], env, append([]core.Any{old}, args...)...).GetContext(env.Context())
+		return val, true, err
+	})
+}
+
+// (compare-and-set! a old new) true when a held old and now holds new
+func _compareAndSetE(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 4); err != nil {
+		return core.Null{}, err
+	}
+	a, err := evalAtom(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	args, err := evalArgs(ast[2:], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	set := false
+	_, err = update(a, ast, env, func(old core.Any) (core.Any, bool, error) {
+		set = old.Equal(args[0])
+		return args[1], set, nil
+	})
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Bool(set), nil
+}
+
+// (set-validator! a f) f is called with each new value, falsy rejects it
+func _setValidatorE(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	a, err := evalAtom(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	fn, err := evalFunc(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	a.SetValidator(fn)
+	return core.Null{}, nil
+}
+
+// (add-watch a key f) f is called with key, a, old and new after each change
+func _addWatch(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 4); err != nil {
+		return core.Null{}, err
+	}
+	a, err := evalAtom(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	key, err := base.Eval(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	fn, err := evalFunc(ast[3], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	a.AddWatch(key, fn)
+	return a, nil
+}
+
+func _removeWatch(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	a, err := evalAtom(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	key, err := base.Eval(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	a.RemoveWatch(key)
+	return a, nil
+}
+
+// compare-and-swap retry loop, next returns the new value and whether to
+// set it; the new value is validated and watches notified once set
+func update(a base.Atom, ast core.Expr, env *base.Env, next func(old core.Any) (core.Any, bool, error)) (core.Any, error) {
+	for {
+		if err := env.Check(); err != nil {
+			return core.Null{}, err
+		}
+		old, version := a.Deref()
+		val, ok, err := next(old)
+		if err != nil || !ok {
+			return old, err
+		}
+		if err := validate(a, ast[0], val, env); err != nil {
+			return core.Null{}, err
+		}
+		if old, ok = a.SetIf(version, val); !ok {
+			continue
+		}
+		for _, watch := range a.Watches() {
+			notify := call(watch.Fn, ast[0], env, watch.Key, a, old, val)
+			if _, err := notify.GetContext(env.Context()); err != nil {
+				return core.Null{}, err
+			}
+		}
+		return val, nil
+	}
+}
+
+func validate(a base.Atom, head core.Any, val core.Any, env *base.Env) error {
+	fn := a.Validator()
+	if fn == nil {
+		return nil
+	}
+	ok, err := call(fn, head, env, val).GetContext(env.Context())
+	if err != nil {
+		return err
+	}
+	if !truthy(ok) {
+		return fmt.Errorf("invalid atom value %#v", val)
+	}
+	return nil
+}
+
+func evalAtom(ast core.Any, env *base.Env) (base.Atom, error) {
+	val, err := base.Eval(ast, env)
+	if err != nil {
+		return base.Atom{}, err
+	}
+	a, ok := val.(base.Atom)
+	if !ok {
+		return base.Atom{}, fmt.Errorf("called with non-atom %#v", val)
+	}
+	return a, nil
+}
@@ -0,0 +1,165 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// check binding pattern: symbol, [p1 p2 & rest] or {"key": p}, with (p default) items
+func checkPattern(pattern core.Any) error {
+	switch pat := core.Unwrap(pattern).(type) {
+	default:
+		return fmt.Errorf("bind expression contained non-symbol %#v", pattern)
+	case core.Symbol:
+		if pat.Val == "&" {
+			return fmt.Errorf("bind expression contained misplaced &")
+		}
+		return nil
+	case core.Vector:
+		for i, item := range pat {
+			if sym, ok := item.(core.Symbol); ok && sym.Val == "&" {
+				if i != len(pat)-2 {
+					return fmt.Errorf("bind expression wanted one pattern after &")
+				}
+				return checkPattern(pat[i+1])
+			}
+			if err := checkItemPattern(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case core.Hash:
+		for _, item := range pat {
+			if err := checkItemPattern(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// pattern or (pattern default)
+func checkItemPattern(item core.Any) error {
+	if expr, ok := core.Unwrap(item).(core.Expr); ok {
+		if len(expr) != 2 {
+			return fmt.Errorf("default wanted (pattern default), got %#v", expr)
+		}
+		return checkPattern(expr[0])
+	}
+	return checkPattern(item)
+}
+
+// split (pattern default) into parts, default is nil when absent
+func splitDefault(item core.Any) (core.Any, core.Any) {
+	if expr, ok := core.Unwrap(item).(core.Expr); ok {
+		return expr[0], expr[1]
+	}
+	return item, nil
+}
+
+// bind pattern to the value of future in local, keeping each binding lazy
+func bindPattern(pattern core.Any, future base.Future, local *base.Env) {
+	switch pat := core.Unwrap(pattern).(type) {
+	case core.Symbol:
+		local.Set(pat, future)
+	case core.Vector:
+		future = future.Memo()
+		for i, item := range pat {
+			if sym, ok := item.(core.Symbol); ok && sym.Val == "&" {
+				bindPattern(pat[i+1], restFuture(pattern, future, i), local)
+				return
+			}
+			sub, def := splitDefault(item)
+			bindPattern(sub, nthFuture(pattern, future, i, def, local), local)
+		}
+	case core.Hash:
+		future = future.Memo()
+		for key, item := range pat {
+			sub, def := splitDefault(item)
+			bindPattern(sub, keyFuture(pattern, future, key, def, local), local)
+		}
+	}
+}
+
+// sequence value for vector destructuring
+func destructureSeq(pattern core.Any, future base.Future) (core.Seq, error) {
+	val, err := future.Get()
+	if err != nil {
+		return nil, err
+	}
+	switch seq := val.(type) {
+	default:
+		return nil, fmt.Errorf("cannot destructure %#v with %v", val, pattern)
+	case core.Null:
+		return core.Vector{}, nil
+	case core.Seq:
+		return seq, nil
+	}
+}
+
+// item at index n, or default; realizes only n+1 items of a lazy seq
+func nthFuture(pattern core.Any, future base.Future, n int, def core.Any, local *base.Env) base.Future {
+	return func() (core.Any, error) {
+		seq, err := destructureSeq(pattern, future)
+		if err != nil {
+			return core.Null{}, err
+		}
+		for i := 0; ; i++ {
+			first, rest, ok, err := seq.Next()
+			if err != nil {
+				return core.Null{}, err
+			}
+			if !ok {
+				break
+			}
+			if i == n {
+				return first, nil
+			}
+			seq = rest
+		}
+		if def != nil {
+			return base.Eval(def, local)
+		}
+		return core.Null{}, nil
+	}
+}
+
+// items from index n onward, still lazy for a lazy seq
+func restFuture(pattern core.Any, future base.Future, n int) base.Future {
+	return func() (core.Any, error) {
+		seq, err := destructureSeq(pattern, future)
+		if err != nil {
+			return core.Null{}, err
+		}
+		return dropSeq(seq, n)
+	}
+}
+
+// value at key, or default
+func keyFuture(pattern core.Any, future base.Future, key core.String, def core.Any, local *base.Env) base.Future {
+	return func() (core.Any, error) {
+		val, err := future.Get()
+		if err != nil {
+			return core.Null{}, err
+		}
+		var item core.Any
+		var ok bool
+		switch hash := val.(type) {
+		default:
+			return core.Null{}, fmt.Errorf("cannot destructure %#v with %v", val, pattern)
+		case core.Null:
+			break
+		case core.Hash:
+			item, ok = hash[key]
+		}
+		if ok {
+			return item, nil
+		}
+		if def != nil {
+			return base.Eval(def, local)
+		}
+		return core.Null{}, nil
+	}
+}
@@ -3,6 +3,7 @@ package builtin
 import (
 	"fmt"
 	"time"
+	"unicode/utf8"
 
 	"github.com/starlight/ocelot/internal/parser"
 	"github.com/starlight/ocelot/pkg/base"
@@ -27,16 +28,52 @@ var Builtin = map[string]base.Func{
 	"and":    _and,
 	"or":     _or,
 	// numbers
-	"add":   _add,
-	"sub":   _sub,
-	"mul":   _mul,
-	"div":   _div,
-	"rem":   _rem,
-	"div*":  _divS,
-	"lt?":   _ltQ,
-	"lteq?": _lteqQ,
-	"gt?":   _gtQ,
-	"gteq?": _gteqQ,
+	"add":            _add,
+	"sub":            _sub,
+	"mul":            _mul,
+	"div":            _div,
+	"rem":            _rem,
+	"div*":           _divS,
+	"abs":            _abs,
+	"sign":           _sign,
+	"min":            _min,
+	"max":            _max,
+	"floor":          _floor,
+	"ceil":           _ceil,
+	"round":          _round,
+	"pow":            _pow,
+	"sqrt":           _sqrt,
+	"exp":            _exp,
+	"ln":             _ln,
+	"log10":          _log10,
+	"truncate":       _truncate,
+	"with-precision": _withPrecision,
+	"lt?":            _ltQ,
+	"lteq?":          _lteqQ,
+	"gt?":            _gtQ,
+	"gteq?":          _gteqQ,
+	// money
+	"money":    _money,
+	"amount":   _amount,
+	"currency": _currency,
+	"allocate": _allocate,
+	// time
+	"now":         _now,
+	"instant":     _instant,
+	"date":        _date,
+	"duration":    _duration,
+	"period":      _period,
+	"in-zone":     _inZone,
+	"zone":        _zone,
+	"format-time": _formatTime,
+	// calendars
+	"calendar":             _calendar,
+	"market-open?":         _marketOpenQ,
+	"trading-day?":         _tradingDayQ,
+	"next-open":            _nextOpen,
+	"previous-close":       _previousClose,
+	"session-bounds":       _sessionBounds,
+	"trading-days-between": _tradingDaysBetween,
 	// special
 	"equal?": _equalQ,
 	"def!":   _defE,
@@ -47,6 +84,7 @@ var Builtin = map[string]base.Func{
 	"let":    _let,
 	"async":  _async,
 	"if":     _if,
+	"match":  _match,
 	"prn":    _prn,
 	"eval":   _eval,
 	"parse":  _parse,
@@ -57,19 +95,126 @@ var Builtin = map[string]base.Func{
 	"try":    _try,
 	"catch":  _func, // alias
 	"wait":   _wait,
+	// promises
+	"await":        _await,
+	"await-all":    _awaitAll,
+	"race":         _race,
+	"cancel":       _cancel,
+	"with-timeout": _withTimeout,
+	// atoms
+	"atom":             _atom,
+	"deref":            _deref,
+	"reset!":           _resetE,
+	"swap!":            _swapE,
+	"compare-and-set!": _compareAndSetE,
+	"set-validator!":   _setValidatorE,
+	"add-watch":        _addWatch,
+	"remove-watch":     _removeWatch,
+	// actors
+	"spawn":      _spawn,
+	"spawn-link": _spawnLink,
+	"self":       _self,
+	"send":       _send,
+	"receive":    _receive,
+	"link":       _link,
+	"monitor":    _monitor,
+	"kill":       _kill,
+	"alive?":     _aliveQ,
+	// parallel
+	"pmap": _pmap,
+	"pfor": _pfor,
+	// channels
+	"chan":    _chan,
+	"put!":    _putE,
+	"take!":   _takeE,
+	"close!":  _closeE,
+	"timeout": _timeout,
+	"alts":    _alts,
+	"select":  _select,
+	// modules
+	"import": _import,
+	// process
+	"config":      _config,
+	"on-shutdown": _onShutdown,
+	// macros
+	"defmacro!":      _defmacroE,
+	"macroexpand":    _macroexpand,
+	"gensym":         _gensym,
+	"quasiquote":     _quasiquote,
+	"unquote":        _unquote,
+	"splice-unquote": _unquote,
 	// type check
-	"type":    _type,
-	"bool?":   _boolQ,
-	"number?": _numberQ,
-	"string?": _stringQ,
-	"symbol?": _symbolQ,
-	"expr?":   _exprQ,
-	"vector?": _vectorQ,
-	"hash?":   _hashQ,
-	"get":     _get,
+	"type":      _type,
+	"bool?":     _boolQ,
+	"number?":   _numberQ,
+	"string?":   _stringQ,
+	"symbol?":   _symbolQ,
+	"keyword?":  _keywordQ,
+	"expr?":     _exprQ,
+	"vector?":   _vectorQ,
+	"hash?":     _hashQ,
+	"money?":    _moneyQ,
+	"instant?":  _instantQ,
+	"date?":     _dateQ,
+	"duration?": _durationQ,
+	"period?":   _periodQ,
+	"chan?":     _chanQ,
+	"promise?":  _promiseQ,
+	"atom?":     _atomQ,
+	"actor?":    _actorQ,
+	"get":       _get,
 	// sequences
-	"empty?": _emptyQ,
-	"count":  _count,
+	"empty?":     _emptyQ,
+	"count":      _count,
+	"seq?":       _seqQ,
+	"range":      _range,
+	"iterate":    _iterate,
+	"repeat":     _repeat,
+	"take":       _take,
+	"drop":       _drop,
+	"take-while": _takeWhile,
+	"lazy-cat":   _lazyCat,
+	// collections
+	"first":       _first,
+	"rest":        _rest,
+	"nth":         _nth,
+	"conj":        _conj,
+	"concat":      _concat,
+	"slice":       _slice,
+	"assoc":       _assoc,
+	"dissoc":      _dissoc,
+	"keys":        _keys,
+	"vals":        _vals,
+	"merge":       _merge,
+	"filter":      _filter,
+	"reduce":      _reduce,
+	"sort-by":     _sortBy,
+	"group-by":    _groupBy,
+	"zip":         _zip,
+	"distinct":    _distinct,
+	"frequencies": _frequencies,
+	"get-in":      _getIn,
+	"assoc-in":    _assocIn,
+	"update-in":   _updateIn,
+	// strings
+	"str":          _str,
+	"format":       _format,
+	"split":        _split,
+	"join":         _join,
+	"trim":         _trim,
+	"upper":        _upper,
+	"lower":        _lower,
+	"replace":      _replace,
+	"substring":    _substring,
+	"starts-with?": _startsWithQ,
+	"ends-with?":   _endsWithQ,
+	"index-of":     _indexOf,
+	"chars":        _chars,
+	"runes":        _runes,
+	"re-find":      _reFind,
+	"re-matches":   _reMatches,
+	"re-replace":   _reReplace,
+	"re-split":     _reSplit,
 }
 
 func _nullQ(ast core.Expr, env *base.Env) (core.Any, error) {
@@ -158,9 +303,19 @@ func _numberQ(ast core.Expr, env *base.Env) (core.Any, error) {
 }
 
 func _add(ast core.Expr, env *base.Env) (core.Any, error) {
+	args, err := evalArgs(ast[1:], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if hasMoney(args) {
+		return addMoney(args, false)
+	}
+	if hasTime(args) {
+		return addTime(args, false)
+	}
 	res := core.Zero.Decimal()
-	for _, item := range ast[1:] {
-		val, err := evalNumber(item, env)
+	for _, arg := range args {
+		val, err := numberArg(arg)
 		if err != nil {
 			return core.Null{}, err
 		}
@@ -170,13 +325,23 @@ func _add(ast core.Expr, env *base.Env) (core.Any, error) {
 }
 
 func _sub(ast core.Expr, env *base.Env) (core.Any, error) {
+	args, err := evalArgs(ast[1:], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if hasMoney(args) {
+		return addMoney(args, true)
+	}
+	if hasTime(args) {
+		return addTime(args, true)
+	}
 	res := core.Zero.Decimal()
-	for i, item := range ast[1:] {
-		val, err := evalNumber(item, env)
+	for i, arg := range args {
+		val, err := numberArg(arg)
 		if err != nil {
 			return core.Null{}, err
 		}
-		if i == 0 && len(ast) > 2 {
+		if i == 0 && len(args) > 1 {
 			res = val.Decimal()
 		} else {
 			res = res.Sub(val.Decimal())
@@ -186,9 +351,16 @@ func _sub(ast core.Expr, env *base.Env) (core.Any, error) {
 }
 
 func _mul(ast core.Expr, env *base.Env) (core.Any, error) {
+	args, err := evalArgs(ast[1:], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if hasMoney(args) {
+		return mulMoney(args)
+	}
 	res := core.One.Decimal()
-	for _, item := range ast[1:] {
-		val, err := evalNumber(item, env)
+	for _, arg := range args {
+		val, err := numberArg(arg)
 		if err != nil {
 			return core.Null{}, err
 		}
@@ -197,57 +369,38 @@ func _mul(ast core.Expr, env *base.Env) (core.Any, error) {
 	return core.Number(res), nil
 }
 
+// (div a b) or (div a b places), quotient truncated toward zero
 func _div(ast core.Expr, env *base.Env) (core.Any, error) {
-	if err := exactLen(ast, 4); err != nil {
-		return core.Null{}, err
-	}
-	val1, err := evalNumber(ast[1], env)
-	if err != nil {
-		return core.Null{}, err
-	}
-	val2, err := evalNumber(ast[2], env)
-	if err != nil {
-		return core.Null{}, err
-	}
-	val3, err := evalNumber(ast[3], env)
-	if err != nil {
-		return core.Null{}, err
-	}
-	q, _ := val1.Decimal().QuoRem(val2.Decimal(), int32(val3.Decimal().IntPart()))
-	return core.Number(q), nil
+	q, _, err := quoRem(ast, env)
+	return core.Number(q), err
 }
 
+// (rem a b) or (rem a b places), remainder of div
 func _rem(ast core.Expr, env *base.Env) (core.Any, error) {
-	if err := exactLen(ast, 4); err != nil {
-		return core.Null{}, err
-	}
-	val1, err := evalNumber(ast[1], env)
-	if err != nil {
-		return core.Null{}, err
-	}
-	val2, err := evalNumber(ast[2], env)
+	_, r, err := quoRem(ast, env)
+	return core.Number(r), err
+}
+
+// rounded to the scope precision and rounding mode
+func _divS(ast core.Expr, env *base.Env) (core.Any, error) {
+	ctx := getMathContext(env)
+	args, err := evalArgs(ast[1:], env)
 	if err != nil {
 		return core.Null{}, err
 	}
-	val3, err := evalNumber(ast[3], env)
-	if err != nil {
-		return core.Null{}, err
+	if hasMoney(args) {
+		return divMoney(args, ctx)
 	}
-	_, r := val1.Decimal().QuoRem(val2.Decimal(), int32(val3.Decimal().IntPart()))
-	return core.Number(r), nil
-}
-
-func _divS(ast core.Expr, env *base.Env) (core.Any, error) {
 	res := core.One.Decimal()
-	for i, item := range ast[1:] {
-		val, err := evalNumber(item, env)
+	for i, arg := range args {
+		val, err := numberArg(arg)
 		if err != nil {
 			return core.Null{}, err
 		}
-		if i == 0 && len(ast) > 2 {
+		if i == 0 && len(args) > 1 {
 			res = val.Decimal()
-		} else {
-			res = res.Div(val.Decimal())
+		} else if res, err = divide(res, val.Decimal(), ctx.precision, ctx.rounding); err != nil {
+			return core.Null{}, err
 		}
 	}
 	return core.Number(res), nil
@@ -294,14 +447,14 @@ func _defnE(ast core.Expr, env *base.Env) (core.Any, error) {
 	if err := exactLen(ast, 4); err != nil {
 		return core.Null{}, err
 	}
-	switch ast[2].(type) {
+	switch core.Unwrap(ast[2]).(type) {
 	default:
 		return core.Null{}, fmt.Errorf("called with non-vector %#v", ast[2])
 	case core.Vector:
 		break
 	}
-	fn := base.Func(_func).Future(cons(ast[0], ast[2:]), env)
-	return _defE(append(ast[:2], fn), env)
+	fn := base.Func(_func).Future(ast, env)
+	return _defE(core.Expr{ast[0], ast[1], fn}, env)
 }
 
 func _let(ast core.Expr, env *base.Env) (core.Any, error) {
@@ -309,7 +462,7 @@ func _let(ast core.Expr, env *base.Env) (core.Any, error) {
 		return core.Null{}, err
 	}
 	var pairs core.Vector
-	switch arg1 := ast[1].(type) {
+	switch arg1 := core.Unwrap(ast[1]).(type) {
 	default:
 		return core.Null{}, fmt.Errorf("called with non-sequence %#v", ast[1])
 	case core.Vector:
@@ -327,13 +480,11 @@ func _let(ast core.Expr, env *base.Env) (core.Any, error) {
 		if len(pairs) == 0 {
 			break
 		}
-		switch sym := pairs[0].(type) {
-		default:
-			return core.Null{}, fmt.Errorf("called with non-symbol %#v", pairs[0])
-		case core.Symbol:
-			newEnv.Set(sym, base.FutureEval(pairs[1], newEnv))
-			pairs = pairs[2:]
+		if err := checkPattern(pairs[0]); err != nil {
+			return core.Null{}, err
 		}
+		bindPattern(pairs[0], base.FutureEval(pairs[1], newEnv), newEnv)
+		pairs = pairs[2:]
 	}
 	return base.FutureEval(ast[2], newEnv), nil
 }
@@ -369,42 +520,41 @@ func _if(ast core.Expr, env *base.Env) (core.Any, error) {
 }
 
 func _func(ast core.Expr, env *base.Env) (core.Any, error) {
-	if err := exactLen(ast, 3); err != nil {
+	if err := rangeLen(ast, 3, 4); err != nil {
 		return core.Null{}, err
 	}
-	switch ast[1].(type) {
-	default:
-		return core.Null{}, fmt.Errorf("called with non-vector %#v", ast[1])
-	case core.Vector:
-		break
-	}
-	binds := ast[1].(core.Vector)
-	symbols := make([]core.Symbol, len(binds))
-	for i, item := range binds {
-		switch sym := item.(type) {
+	// optional name: (func name [binds] body)
+	name := "anonymous func"
+	if len(ast) == 4 {
+		switch sym := ast[1].(type) {
 		default:
-			return core.Null{}, fmt.Errorf("bind expression contained non-symbol %#v", item)
+			return core.Null{}, fmt.Errorf("called with non-symbol name %#v", ast[1])
 		case core.Symbol:
-			symbols[i] = sym
-			break
+			name = sym.Val
 		}
+		ast = cons(ast[0], ast[2:])
+	}
+	params, ok := core.Unwrap(ast[1]).(core.Vector)
+	if !ok {
+		return core.Null{}, fmt.Errorf("called with non-vector %#v", ast[1])
+	}
+	sig, err := parseSignature(name, params)
+	if err != nil {
+		return core.Null{}, err
 	}
 	body := ast[2]
 	fn := func(args core.Expr, outer *base.Env) (core.Any, error) {
-		err := exactLen(args, len(symbols)+1)
-		if err != nil {
+		// lexical scope, but the caller's context and quota
+		local := base.NewCallEnv(outer, env)
+		// bind syms to args in local, but lazy eval args in outer
+		if err := sig.bind(args, outer, local); err != nil {
 			return core.Null{}, err
 		}
-		local := base.NewEnv(env)
-		for i, sym := range symbols {
-			// bind sym to arg in local, but lazy eval arg in outer
-			local.Set(sym, base.FutureEval(args[i+1], outer))
-		}
 		// future that places breaks in error trace
 		future := func() (val core.Any, err error) {
 			val, err = base.Eval(body, local)
-			if err != nil {
-				err = fmt.Errorf("error\n  %v", err)
+			if err != nil && !base.Stopped(err) {
+				err = fmt.Errorf("error\n  %w", err)
 			}
 			return
 		}
@@ -424,6 +574,9 @@ func _prn(ast core.Expr, env *base.Env) (core.Any, error) {
 			if err != nil {
 				return core.Null{}, err
 			}
+			if err := core.PrintError(val); err != nil {
+				return core.Null{}, err
+			}
 			str += fmt.Sprintf("%v", val)
 		}
 		fmt.Println(str)
@@ -479,6 +632,22 @@ func _symbolQ(ast core.Expr, env *base.Env) (core.Any, error) {
 	}
 }
 
+func _keywordQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	switch val.(type) {
+	default:
+		return core.Bool(false), nil
+	case core.Keyword:
+		return core.Bool(true), nil
+	}
+}
+
 func _boolQ(ast core.Expr, env *base.Env) (core.Any, error) {
 	if err := exactLen(ast, 2); err != nil {
 		return core.Null{}, err
@@ -527,33 +696,21 @@ func _hashQ(ast core.Expr, env *base.Env) (core.Any, error) {
 	}
 }
 
+// (get hash key) or (get vector index)
 func _get(ast core.Expr, env *base.Env) (core.Any, error) {
 	if err := exactLen(ast, 3); err != nil {
 		return core.Null{}, err
 	}
-	arg1, err := base.Eval(ast[1], env)
+	coll, err := base.Eval(ast[1], env)
 	if err != nil {
 		return core.Null{}, err
 	}
-	switch map1 := arg1.(type) {
-	default:
-		return core.Null{}, fmt.Errorf("called with non-map %#v", ast[1])
-	case core.Hash:
-		key, err := base.Eval(ast[2], env)
-		if err != nil {
-			return core.Null{}, err
-		}
-		switch str := key.(type) {
-		default:
-			return core.Null{}, fmt.Errorf("called with non-string key %#v", ast[2])
-		case core.String:
-			val, ok := map1[str]
-			if ok {
-				return val, nil
-			}
-		}
-		return core.Null{}, nil
+	key, err := base.Eval(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
 	}
+	val, _, err := lookup(coll, key)
+	return val, err
 }
 
 func _count(ast core.Expr, env *base.Env) (core.Any, error) {
@@ -574,16 +731,33 @@ func _count(ast core.Expr, env *base.Env) (core.Any, error) {
 	case core.Expr:
 		cnt = len(any)
 		break
+	case core.String:
+		cnt = utf8.RuneCountInString(any.Val)
+		break
+	case core.Seq:
+		items, err := items(env, any)
+		if err != nil {
+			return core.Null{}, err
+		}
+		cnt = len(items)
+		break
 	}
 	return core.NewNumber(cnt), nil
 }
 
 func _emptyQ(ast core.Expr, env *base.Env) (core.Any, error) {
-	cnt, err := _count(ast, env)
+	val, err := oneArg(ast, env)
 	if err != nil {
 		return core.Null{}, err
 	}
-	return core.Bool(cnt.Equal(core.Zero)), nil
+	switch seq := val.(type) {
+	default:
+		return core.Null{}, fmt.Errorf("called with non-collection %#v", val)
+	case core.Seq:
+		// only realize the first item
+		_, _, ok, err := seq.Next()
+		return core.Bool(!ok), err
+	}
 }
 
 func _equalQ(ast core.Expr, env *base.Env) (core.Any, error) {
@@ -607,70 +781,42 @@ func _equalQ(ast core.Expr, env *base.Env) (core.Any, error) {
 }
 
 func _ltQ(ast core.Expr, env *base.Env) (core.Any, error) {
-	if err := exactLen(ast, 3); err != nil {
-		return core.Null{}, err
-	}
-	val1, err := evalNumber(ast[1], env)
+	cmp, err := evalCompare(ast, env)
 	if err != nil {
 		return core.Null{}, err
 	}
-	val2, err := evalNumber(ast[2], env)
-	if err != nil {
-		return core.Null{}, err
-	}
-	return core.Bool(val1.Decimal().LessThan(val2.Decimal())), nil
+	return core.Bool(cmp < 0), nil
 }
 
 func _lteqQ(ast core.Expr, env *base.Env) (core.Any, error) {
-	if err := exactLen(ast, 3); err != nil {
-		return core.Null{}, err
-	}
-	val1, err := evalNumber(ast[1], env)
+	cmp, err := evalCompare(ast, env)
 	if err != nil {
 		return core.Null{}, err
 	}
-	val2, err := evalNumber(ast[2], env)
-	if err != nil {
-		return core.Null{}, err
-	}
-	return core.Bool(val1.Decimal().LessThanOrEqual(val2.Decimal())), nil
+	return core.Bool(cmp <= 0), nil
 }
 
 func _gtQ(ast core.Expr, env *base.Env) (core.Any, error) {
-	if err := exactLen(ast, 3); err != nil {
-		return core.Null{}, err
-	}
-	val1, err := evalNumber(ast[1], env)
-	if err != nil {
-		return core.Null{}, err
-	}
-	val2, err := evalNumber(ast[2], env)
+	cmp, err := evalCompare(ast, env)
 	if err != nil {
 		return core.Null{}, err
 	}
-	return core.Bool(val1.Decimal().GreaterThan(val2.Decimal())), nil
+	return core.Bool(cmp > 0), nil
 }
 
 func _gteqQ(ast core.Expr, env *base.Env) (core.Any, error) {
-	if err := exactLen(ast, 3); err != nil {
-		return core.Null{}, err
-	}
-	val1, err := evalNumber(ast[1], env)
-	if err != nil {
-		return core.Null{}, err
-	}
-	val2, err := evalNumber(ast[2], env)
+	cmp, err := evalCompare(ast, env)
 	if err != nil {
 		return core.Null{}, err
 	}
-	return core.Bool(val1.Decimal().GreaterThanOrEqual(val2.Decimal())), nil
+	return core.Bool(cmp >= 0), nil
 }
 
 func _quote(ast core.Expr, env *base.Env) (core.Any, error) {
 	if err := exactLen(ast, 2); err != nil {
 		return core.Null{}, err
 	}
-	return ast[1], nil
+	return core.Strip(ast[1]), nil
 }
 
 func _eval(ast core.Expr, env *base.Env) (core.Any, error) {
@@ -693,40 +839,12 @@ func _parse(ast core.Expr, env *base.Env) (core.Any, error) {
 	default:
 		return core.Null{}, fmt.Errorf("called with non-string %#v", ast[1])
 	case core.String:
-		arg, err := parser.Parse("parse", []byte(str.String()))
+		arg, err := parser.ParseSource("parse", []byte(str.String()))
 		if err != nil {
 			return core.Null{}, err
 		}
-		return arg.(core.Any), nil
-	}
-}
-
-// converts lazy-futures to async-futures
-func _async(ast core.Expr, env *base.Env) (res core.Any, err error) {
-	res = core.Null{}
-	if err = exactLen(ast, 2); err != nil {
-		return
-	}
-	switch arg := ast[1].(type) {
-	default:
-		err = fmt.Errorf("called with non-symbol %#v", ast[1])
-	case core.Symbol:
-		err = env.Async(arg)
-	case core.Vector:
-	loop:
-		for _, item := range arg {
-			switch sym := item.(type) {
-			default:
-				err = fmt.Errorf("called with non-symbol %#v", item)
-				break loop
-			case core.Symbol:
-				if err = env.Async(sym); err != nil {
-					break loop
-				}
-			}
-		}
+		return core.Strip(arg), nil
 	}
-	return
 }
 
 func _apply(ast core.Expr, env *base.Env) (core.Any, error) {
@@ -775,17 +893,20 @@ func _map(ast core.Expr, env *base.Env) (core.Any, error) {
 		if err != nil {
 			return core.Null{}, err
 		}
-		switch val2.(type) {
+		switch seq := val2.(type) {
 		default:
-			return core.Null{}, fmt.Errorf("called with non-vector: %#v", val2)
+			return core.Null{}, fmt.Errorf("called with non-sequence: %#v", val2)
 		case core.Vector:
 			break
+		case core.Seq:
+			return mapSeq(fn, ast[1], env, seq), nil
 		}
 		res := make(core.Vector, len(val2.(core.Vector)))
 		for i, item := range val2.(core.Vector) {
 			ast2 := core.Expr{ast[1], core.Expr{core.NewSymbol("quote", nil), item}}
 			res[i] = fn.Future(ast2, env)
 		}
+		// counted by evalVector
 		return base.FutureEval(res, env), nil
 	}
 }
@@ -794,15 +915,17 @@ func _wait(ast core.Expr, env *base.Env) (core.Any, error) {
 	if err := exactLen(ast, 2); err != nil {
 		return core.Null{}, err
 	}
-	arg, err := evalNumber(ast[1], env)
+	dur, err := evalDuration(ast[1], env)
 	if err != nil {
 		return core.Null{}, err
 	}
-	dur, err := time.ParseDuration(fmt.Sprintf("%ss", arg))
-	if err != nil {
-		return core.Null{}, err
+	timer := time.NewTimer(dur)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-env.Context().Done():
+		return core.Null{}, env.Check()
 	}
-	time.Sleep(dur)
 	return core.Null{}, nil
 }
 
@@ -814,26 +937,89 @@ func _throw(ast core.Expr, env *base.Env) (core.Any, error) {
 	if err != nil {
 		return core.Null{}, err
 	}
-	return core.Null{}, fmt.Errorf("%s", arg)
+	return core.Null{}, base.NewEvalError(arg)
 }
 
+// (try body (catch [e] ...) (catch filter [e] ...) (finally ...))
 func _try(ast core.Expr, env *base.Env) (core.Any, error) {
-	if err := exactLen(ast, 3); err != nil {
+	if err := minLen(ast, 3); err != nil {
 		return core.Null{}, err
 	}
+	clauses := ast[2:]
+	var finally core.Expr
+	if form, ok := core.Unwrap(clauses[len(clauses)-1]).(core.Expr); ok && isHead(form, "finally") {
+		finally = cons(core.NewSymbol("do", nil), form[1:])
+		clauses = clauses[:len(clauses)-1]
+	}
 	res, err := base.Eval(ast[1], env)
-	if err != nil {
-		res2, err2 := base.Eval(ast[2], env)
-		if err2 != nil {
-			return core.Null{}, err
+	// cancellation and quotas are not catchable
+	if err != nil && !base.Stopped(err) {
+		thrown := base.ErrorValue(err)
+		for _, clause := range clauses {
+			handler, ok, err2 := catchHandler(clause, thrown, env)
+			if err2 != nil {
+				return core.Null{}, err2
+			}
+			if !ok {
+				continue
+			}
+			call := handler.Future(core.Expr{clause, quote(thrown)}, env)
+			if finally == nil {
+				// lazy-call catch-function
+				return call, nil
+			}
+			res, err = call.Get()
+			break
 		}
-		switch catch := res2.(type) {
-		default:
-			return core.Null{}, fmt.Errorf("called with non-function catch %#v", res2)
-		case base.Func:
-			// lazy-call catch-function
-			return catch.Future(core.Expr{ast[2], core.String{Val: err.Error()}}, env), nil
+	}
+	if finally != nil {
+		if _, err2 := base.Eval(finally, env); err2 != nil {
+			return core.Null{}, err2
+		}
+	}
+	return res, err
+}
+
+// handler for clause if it catches thrown
+func catchHandler(clause core.Any, thrown core.Any, env *base.Env) (base.Func, bool, error) {
+	filter := core.Any(core.Null{})
+	if form, ok := core.Unwrap(clause).(core.Expr); ok && isHead(form, "catch") && len(form) == 4 {
+		// (catch filter [e] body)
+		val, err := base.Eval(form[1], env)
+		if err != nil {
+			return nil, false, err
+		}
+		filter = val
+		clause = cons(form[0], form[2:])
+	}
+	val, err := base.Eval(clause, env)
+	if err != nil {
+		return nil, false, err
+	}
+	handler, ok := val.(base.Func)
+	if !ok {
+		return nil, false, fmt.Errorf("called with non-function catch %#v", val)
+	}
+	switch test := filter.(type) {
+	default:
+		return nil, false, fmt.Errorf("catch called with invalid filter %#v", filter)
+	case core.Null:
+		return handler, true, nil
+	case core.String:
+		// match error type
+		return handler, test.Equal(errorType(thrown)), nil
+	case base.Func:
+		res, err := test.Future(core.Expr{clause, quote(thrown)}, env).Get()
+		return handler, truthy(res), err
+	}
+}
+
+// "type" of a thrown hash, or the type name of other values
+func errorType(val core.Any) core.Any {
+	if hash, ok := val.(core.Hash); ok {
+		if str, ok := hash[core.String{Val: "type"}].(core.String); ok {
+			return str
 		}
 	}
-	return res, nil
+	return core.String{Val: fmt.Sprintf("%T", val)}
 }
@@ -0,0 +1,157 @@
+package builtin
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/calendar"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// loaded calendars by absolute path
+var calendars sync.Map
+
+// (calendar "xnys.toml") found like import
+func _calendar(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	file, err := evalString(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	path, err := resolveModule(file.Val, importingDir(ast))
+	if err != nil {
+		return core.Null{}, fmt.Errorf("calendar %q not found", file.Val)
+	}
+	if cal, ok := calendars.Load(path); ok {
+		return cal.(*calendar.Calendar), nil
+	}
+	cal, err := calendar.Load(path)
+	if err != nil {
+		return core.Null{}, err
+	}
+	calendars.Store(path, cal)
+	return cal, nil
+}
+
+// (market-open? cal t)
+func _marketOpenQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	cal, t, err := evalCalendarTime(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Bool(cal.IsOpen(t)), nil
+}
+
+// (trading-day? cal date)
+func _tradingDayQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	cal, t, err := evalCalendarTime(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Bool(cal.IsTradingDay(t)), nil
+}
+
+// (next-open cal t) first open after t
+func _nextOpen(ast core.Expr, env *base.Env) (core.Any, error) {
+	cal, t, err := evalCalendarTime(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	open, err := cal.NextOpen(t)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Instant{Val: open}, nil
+}
+
+// (previous-close cal t) last close at or before t
+func _previousClose(ast core.Expr, env *base.Env) (core.Any, error) {
+	cal, t, err := evalCalendarTime(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	close, err := cal.PreviousClose(t)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Instant{Val: close}, nil
+}
+
+// (session-bounds cal date) [open close], or null when closed all day
+func _sessionBounds(ast core.Expr, env *base.Env) (core.Any, error) {
+	cal, t, err := evalCalendarTime(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	open, close, ok := cal.Session(t)
+	if !ok {
+		return core.Null{}, nil
+	}
+	return core.Vector{core.Instant{Val: open}, core.Instant{Val: close}}, nil
+}
+
+// (trading-days-between cal from to) counting from, not to
+func _tradingDaysBetween(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 4); err != nil {
+		return core.Null{}, err
+	}
+	cal, err := evalCalendar(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	from, err := evalCalendarDay(cal, ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	to, err := evalCalendarDay(cal, ast[3], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.NewNumber(cal.TradingDaysBetween(from, to)), nil
+}
+
+// (op cal t)
+func evalCalendarTime(ast core.Expr, env *base.Env) (*calendar.Calendar, time.Time, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return nil, time.Time{}, err
+	}
+	cal, err := evalCalendar(ast[1], env)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	t, err := evalCalendarDay(cal, ast[2], env)
+	return cal, t, err
+}
+
+func evalCalendar(ast core.Any, env *base.Env) (*calendar.Calendar, error) {
+	val, err := base.Eval(ast, env)
+	if err != nil {
+		return nil, err
+	}
+	cal, ok := val.(*calendar.Calendar)
+	if !ok {
+		return nil, fmt.Errorf("called with non-calendar %#v", val)
+	}
+	return cal, nil
+}
+
+// instant, or date as local midnight in the calendar zone
+func evalCalendarDay(cal *calendar.Calendar, ast core.Any, env *base.Env) (time.Time, error) {
+	val, err := base.Eval(ast, env)
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch arg := val.(type) {
+	default:
+		return time.Time{}, fmt.Errorf("called with non-instant %#v", val)
+	case core.Instant:
+		return arg.Val, nil
+	case core.Date:
+		year, month, day := arg.Val.Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, cal.Location), nil
+	}
+}
@@ -0,0 +1,202 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// (chan) unbuffered, (chan n) buffered, (chan n :dropping) or (chan n :sliding)
+func _chan(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 1, 3); err != nil {
+		return core.Null{}, err
+	}
+	size := int64(0)
+	if len(ast) > 1 {
+		num, err := evalNumber(ast[1], env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		size = num.Decimal().IntPart()
+		if size < 0 {
+			return core.Null{}, fmt.Errorf("negative buffer size %d", size)
+		}
+	}
+	mode := base.Block
+	if len(ast) == 3 {
+		key, ok := core.Unwrap(ast[2]).(core.Keyword)
+		if !ok {
+			return core.Null{}, fmt.Errorf("called with non-keyword %#v", ast[2])
+		}
+		mode = key.Val
+	}
+	if err := env.Alloc(int(size)); err != nil {
+		return core.Null{}, err
+	}
+	return base.NewChan(int(size), mode)
+}
+
+func _chanQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	val, err := oneArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	_, ok := val.(base.Chan)
+	return core.Bool(ok), nil
+}
+
+// (put! c val) true once taken or buffered, false when closed
+func _putE(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	c, err := evalChan(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	// blocks when resolved
+	put := func() (core.Any, error) {
+		ok, err := c.Put(env.Context(), val)
+		return core.Bool(ok), waitError(err, env)
+	}
+	return base.Future(put), nil
+}
+
+// (take! c) next value, null once closed and empty
+func _takeE(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	c, err := evalChan(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	// blocks when resolved
+	take := func() (core.Any, error) {
+		val, err := c.Take(env.Context())
+		return val, waitError(err, env)
+	}
+	return base.Future(take), nil
+}
+
+func _closeE(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	c, err := evalChan(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	c.Close()
+	return core.Null{}, nil
+}
+
+// (timeout t) chan closed after seconds or a duration, a port for alts and select
+func _timeout(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	dur, err := evalDuration(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return base.Timeout(dur), nil
+}
+
+// (alts [c [c val]...]) [val port] of the first ready take or put
+func _alts(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	ports, ok := val.(core.Vector)
+	if !ok {
+		return core.Null{}, fmt.Errorf("called with non-vector %#v", val)
+	}
+	ops := make([]base.ChanOp, len(ports))
+	for i, port := range ports {
+		if ops[i], err = chanOp(port); err != nil {
+			return core.Null{}, err
+		}
+	}
+	// blocks when resolved
+	alts := func() (core.Any, error) {
+		i, val, err := base.Alts(env.Context(), ops)
+		if err != nil {
+			return core.Null{}, waitError(err, env)
+		}
+		return core.Vector{val, ports[i]}, nil
+	}
+	return base.Future(alts), nil
+}
+
+// (select port fn ...) calls fn of the first ready port with its value,
+// a port is a chan to take from or [c val] to put
+func _select(ast core.Expr, env *base.Env) (core.Any, error) {
+	if len(ast)%2 != 1 {
+		return core.Null{}, fmt.Errorf("port missing function")
+	}
+	ops := make([]base.ChanOp, 0, len(ast)/2)
+	fns := make([]base.Func, 0, len(ast)/2)
+	for i := 1; i < len(ast); i += 2 {
+		port, err := base.Eval(ast[i], env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		op, err := chanOp(port)
+		if err != nil {
+			return core.Null{}, err
+		}
+		fn, err := evalFunc(ast[i+1], env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		ops = append(ops, op)
+		fns = append(fns, fn)
+	}
+	// blocks when resolved, then tail-calls fn
+	sel := func() (core.Any, error) {
+		i, val, err := base.Alts(env.Context(), ops)
+		if err != nil {
+			return core.Null{}, waitError(err, env)
+		}
+		return call(fns[i], ast[2*i+2], env, val), nil
+	}
+	return base.Future(sel), nil
+}
+
+// c takes, [c val] puts
+func chanOp(port core.Any) (base.ChanOp, error) {
+	switch arg := port.(type) {
+	case base.Chan:
+		return base.ChanOp{Chan: arg}, nil
+	case core.Vector:
+		if len(arg) != 2 {
+			break
+		}
+		if c, ok := arg[0].(base.Chan); ok {
+			return base.ChanOp{Chan: c, Put: true, Val: arg[1]}, nil
+		}
+	}
+	return base.ChanOp{}, fmt.Errorf("wanted chan or [chan value], got %#v", port)
+}
+
+func evalChan(ast core.Any, env *base.Env) (base.Chan, error) {
+	val, err := base.Eval(ast, env)
+	if err != nil {
+		return base.Chan{}, err
+	}
+	c, ok := val.(base.Chan)
+	if !ok {
+		return base.Chan{}, fmt.Errorf("called with non-chan %#v", val)
+	}
+	return c, nil
+}
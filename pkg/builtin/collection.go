@@ -0,0 +1,773 @@
+package builtin
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+func _first(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	seq, err := evalSeq(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	first, _, _, err := seq.Next()
+	return first, err
+}
+
+func _rest(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	seq, err := evalSeq(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	_, rest, _, err := seq.Next()
+	if err != nil {
+		return core.Null{}, err
+	}
+	return rest, nil
+}
+
+// (nth seq n) or (nth seq n default)
+func _nth(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 3, 4); err != nil {
+		return core.Null{}, err
+	}
+	seq, err := evalSeq(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	num, err := evalNumber(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	n := num.Decimal().IntPart()
+	for i := int64(0); n >= 0; i++ {
+		first, rest, ok, err := seq.Next()
+		if err != nil {
+			return core.Null{}, err
+		}
+		if !ok {
+			break
+		}
+		if i == n {
+			return first, nil
+		}
+		seq = rest
+	}
+	if len(ast) == 4 {
+		return base.FutureEval(ast[3], env), nil
+	}
+	return core.Null{}, fmt.Errorf("index %d out of range", n)
+}
+
+// (conj coll item...): append to vectors and exprs, add [key val] to hashes
+func _conj(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := minLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	coll, err := base.Eval(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	items, err := evalArgs(ast[2:], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if err := env.Alloc(len(items)); err != nil {
+		return core.Null{}, err
+	}
+	switch arg := coll.(type) {
+	default:
+		return core.Null{}, fmt.Errorf("called with non-collection %#v", coll)
+	case core.Null:
+		return core.Vector(items), nil
+	case core.Vector:
+		return append(append(core.Vector{}, arg...), items...), nil
+	case core.Expr:
+		return append(append(core.Expr{}, arg...), items...), nil
+	case core.Hash:
+		res := copyHash(arg)
+		for _, item := range items {
+			pair, ok := item.(core.Vector)
+			if !ok || len(pair) != 2 {
+				return core.Null{}, fmt.Errorf("wanted [key value] pair, got %#v", item)
+			}
+			key, err := hashKey(pair[0])
+			if err != nil {
+				return core.Null{}, err
+			}
+			res[key] = pair[1]
+		}
+		return res, nil
+	case core.Seq:
+		// prepend to lazy seqs
+		var seq core.Seq = arg
+		for _, item := range items {
+			seq = core.Cons{First: item, Rest: seq}
+		}
+		return seq, nil
+	}
+}
+
+// (concat seq...) as a vector, or lazily when any seq is lazy
+func _concat(ast core.Expr, env *base.Env) (core.Any, error) {
+	seqs := make([]core.Seq, len(ast)-1)
+	lazy := false
+	for i, item := range ast[1:] {
+		seq, err := evalSeq(item, env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		switch seq.(type) {
+		case core.Cons, core.LazySeq:
+			lazy = true
+		}
+		seqs[i] = seq
+	}
+	if lazy {
+		return concatSeq(seqs), nil
+	}
+	res := core.Vector{}
+	for _, seq := range seqs {
+		items, err := items(env, seq)
+		if err != nil {
+			return core.Null{}, err
+		}
+		res = append(res, items...)
+	}
+	return alloc(env, res)
+}
+
+func concatSeq(seqs []core.Seq) core.Seq {
+	return core.NewLazySeq(func() (core.Seq, error) {
+		for len(seqs) > 0 {
+			first, rest, ok, err := seqs[0].Next()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				next := append([]core.Seq{rest}, seqs[1:]...)
+				return core.Cons{First: first, Rest: concatSeq(next)}, nil
+			}
+			seqs = seqs[1:]
+		}
+		return core.Vector{}, nil
+	})
+}
+
+// (slice seq start) or (slice seq start end)
+func _slice(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 3, 4); err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	seq, ok := val.(core.Seq)
+	if !ok {
+		return core.Null{}, fmt.Errorf("called with non-sequence %#v", val)
+	}
+	items, err := items(env, seq)
+	if err != nil {
+		return core.Null{}, err
+	}
+	start, end, err := evalBounds(ast[2:], len(items), env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	switch val.(type) {
+	case core.Expr:
+		return alloc(env, append(core.Expr{}, items[start:end]...))
+	}
+	return alloc(env, append(core.Vector{}, items[start:end]...))
+}
+
+// start and optional end index within length
+func evalBounds(ast core.Expr, length int, env *base.Env) (int, int, error) {
+	bounds := []int{0, length}
+	for i, item := range ast {
+		num, err := evalNumber(item, env)
+		if err != nil {
+			return 0, 0, err
+		}
+		bounds[i] = int(num.Decimal().IntPart())
+	}
+	start, end := bounds[0], bounds[1]
+	if start < 0 || end > length || start > end {
+		return 0, 0, fmt.Errorf("bounds [%d %d] out of range for length %d", start, end, length)
+	}
+	return start, end, nil
+}
+
+// (assoc coll key val ...)
+func _assoc(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := minLen(ast, 4); err != nil {
+		return core.Null{}, err
+	}
+	if len(ast)%2 != 0 {
+		return core.Null{}, fmt.Errorf("key missing value")
+	}
+	args, err := evalArgs(ast[1:], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if err := env.Alloc(len(args) / 2); err != nil {
+		return core.Null{}, err
+	}
+	coll := args[0]
+	for i := 1; i < len(args); i += 2 {
+		coll, err = assoc(coll, args[i], args[i+1])
+		if err != nil {
+			return core.Null{}, err
+		}
+	}
+	return coll, nil
+}
+
+// copy of coll with key set to val
+func assoc(coll core.Any, key core.Any, val core.Any) (core.Any, error) {
+	switch arg := coll.(type) {
+	default:
+		return core.Null{}, fmt.Errorf("cannot assoc into %#v", coll)
+	case core.Null:
+		str, err := hashKey(key)
+		if err != nil {
+			return core.Null{}, err
+		}
+		return core.Hash{str: val}, nil
+	case core.Hash:
+		str, err := hashKey(key)
+		if err != nil {
+			return core.Null{}, err
+		}
+		res := copyHash(arg)
+		res[str] = val
+		return res, nil
+	case core.Vector:
+		n, err := index(key, len(arg)+1)
+		if err != nil {
+			return core.Null{}, err
+		}
+		res := append(core.Vector{}, arg...)
+		if n == len(arg) {
+			return append(res, val), nil
+		}
+		res[n] = val
+		return res, nil
+	}
+}
+
+// (dissoc hash key...)
+func _dissoc(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := minLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	hash, err := evalHash(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	keys, err := evalArgs(ast[2:], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	res := copyHash(hash)
+	for _, item := range keys {
+		key, err := hashKey(item)
+		if err != nil {
+			return core.Null{}, err
+		}
+		delete(res, key)
+	}
+	return res, nil
+}
+
+// keys ordered
+func _keys(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	hash, err := evalHash(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	pairs := hash.Pairs()
+	res := make(core.Vector, len(pairs))
+	for i, pair := range pairs {
+		res[i] = pair.(core.Vector)[0]
+	}
+	return alloc(env, res)
+}
+
+// values ordered by key
+func _vals(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	hash, err := evalHash(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	pairs := hash.Pairs()
+	res := make(core.Vector, len(pairs))
+	for i, pair := range pairs {
+		res[i] = pair.(core.Vector)[1]
+	}
+	return alloc(env, res)
+}
+
+// (merge hash...) later keys win
+func _merge(ast core.Expr, env *base.Env) (core.Any, error) {
+	res := core.Hash{}
+	for _, item := range ast[1:] {
+		hash, err := evalHash(item, env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		for key, val := range hash {
+			res[key] = val
+		}
+	}
+	return alloc(env, res)
+}
+
+// (filter pred seq) as a vector, or lazily for lazy seqs
+func _filter(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	fn, err := evalFunc(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	seq, err := evalSeq(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	switch seq.(type) {
+	case core.Cons, core.LazySeq:
+		return filterSeq(fn, ast[1], env, seq), nil
+	}
+	items, err := items(env, seq)
+	if err != nil {
+		return core.Null{}, err
+	}
+	res := core.Vector{}
+	for _, item := range items {
+		test, err := call(fn, ast[1], env, item).Get()
+		if err != nil {
+			return core.Null{}, err
+		}
+		if truthy(test) {
+			res = append(res, item)
+		}
+	}
+	return alloc(env, res)
+}
+
+func filterSeq(fn base.Func, head core.Any, env *base.Env, seq core.Seq) core.Seq {
+	return core.NewLazySeq(func() (core.Seq, error) {
+		for {
+			first, rest, ok, err := seq.Next()
+			if err != nil || !ok {
+				return core.Vector{}, err
+			}
+			test, err := call(fn, head, env, first).Get()
+			if err != nil {
+				return nil, err
+			}
+			if truthy(test) {
+				return core.Cons{First: first, Rest: filterSeq(fn, head, env, rest)}, nil
+			}
+			seq = rest
+		}
+	})
+}
+
+// (reduce f seq) or (reduce f init seq), the last call is a tail-call
+func _reduce(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 3, 4); err != nil {
+		return core.Null{}, err
+	}
+	fn, err := evalFunc(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	seq, err := evalSeq(ast[len(ast)-1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	var acc core.Any
+	if len(ast) == 4 {
+		acc, err = base.Eval(ast[2], env)
+	} else {
+		var ok bool
+		acc, seq, ok, err = seq.Next()
+		if err == nil && !ok {
+			// (f) for empty seq without init
+			return call(fn, ast[1], env), nil
+		}
+	}
+	if err != nil {
+		return core.Null{}, err
+	}
+	for {
+		first, rest, ok, err := seq.Next()
+		if err != nil {
+			return core.Null{}, err
+		}
+		if !ok {
+			return acc, nil
+		}
+		step := call(fn, ast[1], env, acc, first)
+		_, _, more, err := rest.Next()
+		if err != nil {
+			return core.Null{}, err
+		}
+		if !more {
+			return step, nil
+		}
+		if acc, err = step.Get(); err != nil {
+			return core.Null{}, err
+		}
+		seq = rest
+	}
+}
+
+// (sort-by keyfn seq) stable
+func _sortBy(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	fn, err := evalFunc(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	items, err := evalItems(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	keys := make([]core.Any, len(items))
+	for i, item := range items {
+		if keys[i], err = call(fn, ast[1], env, item).Get(); err != nil {
+			return core.Null{}, err
+		}
+	}
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+		var cmp int
+		cmp, err = compare(keys[order[i]], keys[order[j]])
+		return cmp < 0
+	})
+	if err != nil {
+		return core.Null{}, err
+	}
+	res := make(core.Vector, len(items))
+	for i, n := range order {
+		res[i] = items[n]
+	}
+	return alloc(env, res)
+}
+
+// (group-by keyfn seq) into a hash of vectors
+func _groupBy(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	fn, err := evalFunc(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	items, err := evalItems(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	res := core.Hash{}
+	for _, item := range items {
+		val, err := call(fn, ast[1], env, item).Get()
+		if err != nil {
+			return core.Null{}, err
+		}
+		key := groupKey(val)
+		group, _ := res[key].(core.Vector)
+		res[key] = append(group, item)
+	}
+	if err := env.Alloc(len(items)); err != nil {
+		return core.Null{}, err
+	}
+	return alloc(env, res)
+}
+
+// (zip seq...) into [a b] vectors, stopping at the shortest
+func _zip(ast core.Expr, env *base.Env) (core.Any, error) {
+	seqs := make([]core.Seq, len(ast)-1)
+	lazy := false
+	for i, item := range ast[1:] {
+		seq, err := evalSeq(item, env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		switch seq.(type) {
+		case core.Cons, core.LazySeq:
+			lazy = true
+		}
+		seqs[i] = seq
+	}
+	if lazy {
+		return zipSeq(seqs), nil
+	}
+	items, err := items(env, zipSeq(seqs))
+	if err != nil {
+		return core.Null{}, err
+	}
+	// each [a b] tuple too
+	if err := env.Alloc(len(items) * len(seqs)); err != nil {
+		return core.Null{}, err
+	}
+	return alloc(env, core.Vector(items))
+}
+
+func zipSeq(seqs []core.Seq) core.Seq {
+	return core.NewLazySeq(func() (core.Seq, error) {
+		if len(seqs) == 0 {
+			return core.Vector{}, nil
+		}
+		tuple := make(core.Vector, len(seqs))
+		rests := make([]core.Seq, len(seqs))
+		for i, seq := range seqs {
+			first, rest, ok, err := seq.Next()
+			if err != nil || !ok {
+				return core.Vector{}, err
+			}
+			tuple[i], rests[i] = first, rest
+		}
+		return core.Cons{First: tuple, Rest: zipSeq(rests)}, nil
+	})
+}
+
+// first occurrence of each item
+func _distinct(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	items, err := evalItems(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	res := core.Vector{}
+outer:
+	for _, item := range items {
+		for _, seen := range res {
+			if seen.Equal(item) {
+				continue outer
+			}
+		}
+		res = append(res, item)
+	}
+	return alloc(env, res)
+}
+
+// hash of item to count
+func _frequencies(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	items, err := evalItems(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	res := core.Hash{}
+	for _, item := range items {
+		key := groupKey(item)
+		cnt, ok := res[key].(core.Number)
+		if !ok {
+			cnt = core.Zero
+		}
+		res[key] = core.Number(cnt.Decimal().Add(core.One.Decimal()))
+	}
+	return alloc(env, res)
+}
+
+// (get-in coll [key...]) or (get-in coll [key...] default)
+func _getIn(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 3, 4); err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	path, err := evalItems(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	for _, key := range path {
+		item, ok, err := lookup(val, key)
+		if err != nil {
+			return core.Null{}, err
+		}
+		if !ok {
+			if len(ast) == 4 {
+				return base.FutureEval(ast[3], env), nil
+			}
+			return core.Null{}, nil
+		}
+		val = item
+	}
+	return val, nil
+}
+
+// (assoc-in coll [key...] val)
+func _assocIn(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 4); err != nil {
+		return core.Null{}, err
+	}
+	coll, err := base.Eval(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	path, err := evalItems(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[3], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if err := env.Alloc(len(path)); err != nil {
+		return core.Null{}, err
+	}
+	return updateIn(coll, path, func(core.Any) (core.Any, error) {
+		return val, nil
+	})
+}
+
+// (update-in coll [key...] f arg...) calls (f old arg...)
+func _updateIn(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := minLen(ast, 4); err != nil {
+		return core.Null{}, err
+	}
+	coll, err := base.Eval(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	path, err := evalItems(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	fn, err := evalFunc(ast[3], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	args, err := evalArgs(ast[4:], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if err := env.Alloc(len(path)); err != nil {
+		return core.Null{}, err
+	}
+	return updateIn(coll, path, func(old core.Any) (core.Any, error) {
+		return call(fn, ast[3], env, append(core.Vector{old}, args...)...).Get()
+	})
+}
+
+// copy of coll with the value at path replaced by update
+func updateIn(coll core.Any, path []core.Any, update func(core.Any) (core.Any, error)) (core.Any, error) {
+	if len(path) == 0 {
+		return update(coll)
+	}
+	old, _, err := lookup(coll, path[0])
+	if err != nil {
+		return core.Null{}, err
+	}
+	val, err := updateIn(old, path[1:], update)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return assoc(coll, path[0], val)
+}
+
+// value at key in hash, or index in vector/expr
+func lookup(coll core.Any, key core.Any) (core.Any, bool, error) {
+	var seq []core.Any
+	switch arg := coll.(type) {
+	default:
+		return core.Null{}, false, fmt.Errorf("cannot get %#v from %#v", key, coll)
+	case core.Null:
+		return core.Null{}, false, nil
+	case core.Hash:
+		str, err := hashKey(key)
+		if err != nil {
+			return core.Null{}, false, err
+		}
+		val, ok := arg[str]
+		if !ok {
+			return core.Null{}, false, nil
+		}
+		return val, true, nil
+	case core.Vector:
+		seq = arg
+	case core.Expr:
+		seq = arg
+	}
+	num, ok := key.(core.Number)
+	if !ok {
+		return core.Null{}, false, fmt.Errorf("called with non-number index %#v", key)
+	}
+	n := num.Decimal().IntPart()
+	if n < 0 || n >= int64(len(seq)) {
+		return core.Null{}, false, nil
+	}
+	return seq[n], true, nil
+}
+
+func hashKey(key core.Any) (core.String, error) {
+	str, ok := key.(core.String)
+	if !ok {
+		return core.String{}, fmt.Errorf("called with non-string key %#v", key)
+	}
+	return str, nil
+}
+
+// hash keys must be strings, other values use their printed form
+func groupKey(val core.Any) core.String {
+	if str, ok := val.(core.String); ok {
+		return str
+	}
+	return core.String{Val: val.String()}
+}
+
+// vector index within length
+func index(key core.Any, length int) (int, error) {
+	num, ok := key.(core.Number)
+	if !ok {
+		return 0, fmt.Errorf("called with non-number index %#v", key)
+	}
+	n := num.Decimal().IntPart()
+	if n < 0 || n >= int64(length) {
+		return 0, fmt.Errorf("index %d out of range", n)
+	}
+	return int(n), nil
+}
+
+func copyHash(hash core.Hash) core.Hash {
+	res := make(core.Hash, len(hash))
+	for key, val := range hash {
+		res[key] = val
+	}
+	return res
+}
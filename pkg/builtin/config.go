@@ -0,0 +1,40 @@
+package builtin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/config"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// (config "key") effective value in the selected profile
+func _config(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	name, err := evalString(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	val, err := config.Get(name.Val)
+	if err != nil {
+		return core.Null{}, err
+	}
+	switch arg := val.(type) {
+	default:
+		return core.Null{}, fmt.Errorf("unsupported config value %#v", val)
+	case string:
+		return core.String{Val: arg}, nil
+	case bool:
+		return core.Bool(arg), nil
+	case int64:
+		return core.Number(decimal.NewFromInt(arg)), nil
+	case float64:
+		return core.Number(decimal.NewFromFloat(arg)), nil
+	case time.Duration:
+		return core.Duration{Val: arg}, nil
+	}
+}
@@ -0,0 +1,115 @@
+package builtin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// printf-like formatting, numbers are formatted from their decimal value:
+// %s %v printed, %q quoted, %d integer, %f %.2f fixed point, %e %g float,
+// with flags -+0 and width
+func format(tmpl string, args []core.Any) (string, error) {
+	var sb strings.Builder
+	n := 0
+	for i := 0; i < len(tmpl); i++ {
+		if tmpl[i] != '%' {
+			sb.WriteByte(tmpl[i])
+			continue
+		}
+		// %[flags][width][.prec]verb
+		j := i + 1
+		for j < len(tmpl) && strings.IndexByte("-+0 ", tmpl[j]) >= 0 {
+			j++
+		}
+		for j < len(tmpl) && (tmpl[j] >= '0' && tmpl[j] <= '9' || tmpl[j] == '.') {
+			j++
+		}
+		if j == len(tmpl) {
+			return "", fmt.Errorf("format %q missing verb", tmpl[i:])
+		}
+		spec, verb := tmpl[i+1:j], tmpl[j]
+		i = j
+		if verb == '%' {
+			sb.WriteByte('%')
+			continue
+		}
+		if n == len(args) {
+			return "", fmt.Errorf("format %%%s%c missing arg", spec, verb)
+		}
+		str, err := formatArg(spec, verb, args[n])
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(str)
+		n++
+	}
+	if n != len(args) {
+		return "", fmt.Errorf("format wanted %d arg(s), got %d", n, len(args))
+	}
+	return sb.String(), nil
+}
+
+func formatArg(spec string, verb byte, arg core.Any) (string, error) {
+	rest := strings.TrimLeft(spec, "-+ 0")
+	flags := spec[:len(spec)-len(rest)]
+	width, prec := rest, ""
+	if dot := strings.IndexByte(rest, '.'); dot >= 0 {
+		width, prec = rest[:dot], rest[dot+1:]
+	}
+	switch verb {
+	default:
+		return "", fmt.Errorf("format verb %%%c not supported", verb)
+	case 's', 'v':
+		return fmt.Sprintf("%"+flags+width+"s", arg.String()), nil
+	case 'q':
+		return fmt.Sprintf("%"+flags+width+"s", arg.GoString()), nil
+	case 'd', 'f', 'e', 'g':
+		break
+	}
+	num, ok := arg.(core.Number)
+	if !ok {
+		return "", fmt.Errorf("format %%%c called with non-number %#v", verb, arg)
+	}
+	dec := num.Decimal()
+	var str string
+	switch verb {
+	case 'd':
+		str = dec.Truncate(0).String()
+	case 'f':
+		if prec == "" {
+			str = dec.String()
+		} else {
+			places, _ := strconv.Atoi(prec)
+			str = dec.StringFixed(int32(places))
+		}
+	case 'e', 'g':
+		str = fmt.Sprintf("%"+"."+prec+string(verb), dec.InexactFloat64())
+		if prec == "" {
+			str = fmt.Sprintf("%"+string(verb), dec.InexactFloat64())
+		}
+	}
+	if strings.ContainsRune(flags, '+') && !strings.HasPrefix(str, "-") {
+		str = "+" + str
+	} else if strings.ContainsRune(flags, ' ') && !strings.HasPrefix(str, "-") {
+		str = " " + str
+	}
+	pad, _ := strconv.Atoi(width)
+	switch {
+	case len(str) >= pad:
+		return str, nil
+	case strings.ContainsRune(flags, '-'):
+		return str + strings.Repeat(" ", pad-len(str)), nil
+	case strings.ContainsRune(flags, '0'):
+		// zeros go after the sign
+		sign := ""
+		if str[0] == '-' || str[0] == '+' || str[0] == ' ' {
+			sign, str = str[:1], str[1:]
+		}
+		return sign + strings.Repeat("0", pad-len(str)-len(sign)) + str, nil
+	default:
+		return strings.Repeat(" ", pad-len(str)) + str, nil
+	}
+}
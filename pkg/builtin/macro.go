@@ -0,0 +1,133 @@
+package builtin
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+func _defmacroE(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 4); err != nil {
+		return core.Null{}, err
+	}
+	switch sym := ast[1].(type) {
+	default:
+		return core.Null{}, fmt.Errorf("called with non-symbol %#v", ast[1])
+	case core.Symbol:
+		fn, err := _func(ast, env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		env.Set(sym, base.Macro(fn.(base.Func)))
+		return core.Null{}, nil
+	}
+}
+
+func _macroexpand(ast core.Expr, env *base.Env) (core.Any, error) {
+	val, err := oneArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return base.MacroExpand(val, env)
+}
+
+var gensymCount uint64
+
+func _gensym(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 1, 2); err != nil {
+		return core.Null{}, err
+	}
+	prefix := "G"
+	if len(ast) == 2 {
+		val, err := base.Eval(ast[1], env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		switch str := val.(type) {
+		default:
+			return core.Null{}, fmt.Errorf("called with non-string %#v", ast[1])
+		case core.String:
+			prefix = str.Val
+		}
+	}
+	num := atomic.AddUint64(&gensymCount, 1)
+	return core.NewSymbol(fmt.Sprintf("%s__%d", prefix, num), nil), nil
+}
+
+func _quasiquote(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	return quasiquote(ast[1], env)
+}
+
+// unquote and splice-unquote are only meaningful inside quasiquote
+func _unquote(ast core.Expr, env *base.Env) (core.Any, error) {
+	return core.Null{}, fmt.Errorf("called outside of quasiquote")
+}
+
+// copy form, evaluating unquoted parts
+func quasiquote(ast core.Any, env *base.Env) (core.Any, error) {
+	switch form := core.Unwrap(ast).(type) {
+	default:
+		return form, nil
+	case core.Expr:
+		if arg, ok := isForm(form, "unquote"); ok {
+			return base.Eval(arg, env)
+		}
+		seq, err := quasiquoteSeq(form, env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		return core.Expr(seq), nil
+	case core.Vector:
+		seq, err := quasiquoteSeq(form, env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		return core.Vector(seq), nil
+	case core.Hash:
+		res := make(core.Hash, len(form))
+		for key, item := range form {
+			val, err := quasiquote(item, env)
+			if err != nil {
+				return core.Null{}, err
+			}
+			res[key] = val
+		}
+		return res, nil
+	}
+}
+
+// quasiquote items of a sequence, splicing in splice-unquoted sequences
+func quasiquoteSeq(seq []core.Any, env *base.Env) ([]core.Any, error) {
+	res := make([]core.Any, 0, len(seq))
+	for _, item := range seq {
+		arg, ok := isForm(item, "splice-unquote")
+		if !ok {
+			val, err := quasiquote(item, env)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, val)
+			continue
+		}
+		val, err := base.Eval(arg, env)
+		if err != nil {
+			return nil, err
+		}
+		switch splice := val.(type) {
+		default:
+			return nil, fmt.Errorf("splice-unquote of non-sequence %#v", val)
+		case core.Null:
+			break
+		case core.Vector:
+			res = append(res, splice...)
+		case core.Expr:
+			res = append(res, splice...)
+		}
+	}
+	return res, nil
+}
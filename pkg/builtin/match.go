@@ -0,0 +1,163 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// (match value pattern body pattern body ...)
+func _match(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := minLen(ast, 4); err != nil {
+		return core.Null{}, err
+	}
+	if len(ast)%2 != 0 {
+		return core.Null{}, fmt.Errorf("pattern missing body")
+	}
+	val, err := base.Eval(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	for i := 2; i < len(ast); i += 2 {
+		local := base.NewEnv(env)
+		ok, err := matchPattern(ast[i], val, local)
+		if err != nil {
+			return core.Null{}, err
+		}
+		if ok {
+			return base.FutureEval(ast[i+1], local), nil
+		}
+	}
+	return core.Null{}, fmt.Errorf("no pattern matched %#v", val)
+}
+
+// test val against pattern, binding symbols in local
+func matchPattern(pattern core.Any, val core.Any, local *base.Env) (bool, error) {
+	switch pat := core.Unwrap(pattern).(type) {
+	default:
+		// String, Number, Bool, Null
+		return pat.Equal(val), nil
+	case core.Symbol:
+		// binder, or _ wildcard
+		if pat.Val != "_" {
+			local.Set(pat, val)
+		}
+		return true, nil
+	case core.Vector:
+		return matchSeq(pat, val, local)
+	case core.Hash:
+		// hash containing keys
+		hash, ok := val.(core.Hash)
+		if !ok {
+			return false, nil
+		}
+		for key, sub := range pat {
+			item, ok := hash[key]
+			if !ok {
+				return false, nil
+			}
+			if ok, err := matchPattern(sub, item, local); !ok || err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	case core.Expr:
+		return matchForm(pat, val, local)
+	}
+}
+
+func matchSeq(pat core.Vector, val core.Any, local *base.Env) (bool, error) {
+	seq, ok := val.(core.Seq)
+	if !ok {
+		return false, nil
+	}
+	for i, sub := range pat {
+		if sym, ok := sub.(core.Symbol); ok && sym.Val == "&" {
+			if i != len(pat)-2 {
+				return false, fmt.Errorf("pattern wanted one pattern after &")
+			}
+			rest, err := dropSeq(seq, 0)
+			if err != nil {
+				return false, err
+			}
+			return matchPattern(pat[i+1], rest, local)
+		}
+		first, rest, ok, err := seq.Next()
+		if err != nil || !ok {
+			return false, err
+		}
+		if ok, err := matchPattern(sub, first, local); !ok || err != nil {
+			return false, err
+		}
+		seq = rest
+	}
+	// no more items than patterns, realizing at most one more
+	_, _, more, err := seq.Next()
+	return !more, err
+}
+
+func matchForm(pat core.Expr, val core.Any, local *base.Env) (bool, error) {
+	if len(pat) == 0 {
+		return core.Null{}.Equal(val), nil
+	}
+	sym, ok := pat[0].(core.Symbol)
+	if !ok {
+		return false, fmt.Errorf("invalid pattern %#v", pat)
+	}
+	switch sym.Val {
+	case "quote":
+		// (quote x) unevaluated literal
+		if err := exactLen(pat, 2); err != nil {
+			return false, err
+		}
+		return core.Strip(pat[1]).Equal(val), nil
+	case "guard":
+		// (guard pattern test)
+		if err := exactLen(pat, 3); err != nil {
+			return false, err
+		}
+		if ok, err := matchPattern(pat[1], val, local); !ok || err != nil {
+			return false, err
+		}
+		test, err := base.Eval(pat[2], local)
+		if err != nil {
+			return false, err
+		}
+		return truthy(test), nil
+	case "or":
+		// (or pattern...) first alternative, keeping only its bindings
+		for _, alt := range pat[1:] {
+			scope := base.NewEnv(local)
+			ok, err := matchPattern(alt, val, scope)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				local.Adopt(scope)
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	// (pred? pattern) type check or other predicate
+	if err := rangeLen(pat, 1, 2); err != nil {
+		return false, err
+	}
+	head, err := base.Eval(sym, local)
+	if err != nil {
+		return false, err
+	}
+	fn, ok := head.(base.Func)
+	if !ok {
+		return false, fmt.Errorf("pattern called with non-function %#v", sym)
+	}
+	test, err := fn.Future(core.Expr{sym, quote(val)}, local).Get()
+	if err != nil || !truthy(test) {
+		return false, err
+	}
+	if len(pat) == 2 {
+		return matchPattern(pat[1], val, local)
+	}
+	return true, nil
+}
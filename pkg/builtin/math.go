@@ -0,0 +1,482 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// precision (digits after the point) and rounding mode for inexact results
+type mathContext struct {
+	precision int32
+	rounding  string
+}
+
+// context key for the innermost with-precision, carried into calls
+type mathContextKey struct{}
+
+// same as decimal.Div, which rounds half away from zero
+var defaultMathContext = mathContext{precision: 16, rounding: "half-up"}
+
+var roundingModes = map[string]bool{
+	"half-even": true,
+	"half-up":   true,
+	"down":      true,
+	"up":        true,
+	"ceiling":   true,
+	"floor":     true,
+}
+
+// innermost with-precision being evaluated, dynamically scoped
+func getMathContext(env *base.Env) mathContext {
+	if ctx, ok := env.Context().Value(mathContextKey{}).(mathContext); ok {
+		return ctx
+	}
+	return defaultMathContext
+}
+
+// (with-precision n body...) or (with-precision n :mode body...)
+func _withPrecision(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := minLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	num, err := evalNumber(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if !num.Decimal().IsInteger() || num.Decimal().IsNegative() {
+		return core.Null{}, fmt.Errorf("called with invalid precision %v", num)
+	}
+	ctx := getMathContext(env)
+	ctx.precision = int32(num.Decimal().IntPart())
+	body := ast[2:]
+	if len(body) > 0 {
+		if key, ok := core.Unwrap(body[0]).(core.Keyword); ok {
+			if ctx.rounding, err = roundingMode(key); err != nil {
+				return core.Null{}, err
+			}
+			body = body[1:]
+		}
+	}
+	scope := env.WithContext(context.WithValue(env.Context(), mathContextKey{}, ctx))
+	return _do(append(core.Expr{ast[0]}, body...), scope)
+}
+
+func roundingMode(key core.Keyword) (string, error) {
+	if !roundingModes[key.Val] {
+		return "", fmt.Errorf("unknown rounding mode %v", key)
+	}
+	return key.Val, nil
+}
+
+// :mode literal
+func evalRoundingMode(ast core.Any) (string, error) {
+	key, ok := core.Unwrap(ast).(core.Keyword)
+	if !ok {
+		return "", fmt.Errorf("called with non-keyword %#v", ast)
+	}
+	return roundingMode(key)
+}
+
+// round to places after the point
+func roundDecimal(d decimal.Decimal, places int32, mode string) decimal.Decimal {
+	switch mode {
+	default: // half-up
+		return d.Round(places)
+	case "half-even":
+		return d.RoundBank(places)
+	case "down":
+		return d.RoundDown(places)
+	case "up":
+		return d.RoundUp(places)
+	case "ceiling":
+		return d.RoundCeil(places)
+	case "floor":
+		return d.RoundFloor(places)
+	}
+}
+
+// exact quotient rounded to places, using the remainder to decide
+func divide(a decimal.Decimal, b decimal.Decimal, places int32, mode string) (decimal.Decimal, error) {
+	if b.IsZero() {
+		return decimal.Zero, fmt.Errorf("division by zero")
+	}
+	q, r := a.QuoRem(b, places)
+	if r.IsZero() {
+		return q, nil
+	}
+	ulp := decimal.New(1, -places)
+	neg := a.Sign()*b.Sign() < 0
+	// twice the remaining fraction against one ulp
+	half := r.Abs().Add(r.Abs()).Cmp(b.Abs().Mul(ulp))
+	away := false
+	switch mode {
+	case "half-up":
+		away = half >= 0
+	case "half-even":
+		away = half > 0 || half == 0 && !q.Shift(places).Mod(decimal.New(2, 0)).IsZero()
+	case "up":
+		away = true
+	case "ceiling":
+		away = !neg
+	case "floor":
+		away = neg
+	}
+	if !away {
+		return q, nil
+	}
+	if neg {
+		return q.Sub(ulp), nil
+	}
+	return q.Add(ulp), nil
+}
+
+// places defaults to the scope precision
+func quoRem(ast core.Expr, env *base.Env) (decimal.Decimal, decimal.Decimal, error) {
+	if err := rangeLen(ast, 3, 4); err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	val1, err := evalNumber(ast[1], env)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	val2, err := evalNumber(ast[2], env)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	places := getMathContext(env).precision
+	if len(ast) == 4 {
+		val3, err := evalNumber(ast[3], env)
+		if err != nil {
+			return decimal.Zero, decimal.Zero, err
+		}
+		places = int32(val3.Decimal().IntPart())
+	}
+	if val2.Decimal().IsZero() {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("division by zero")
+	}
+	q, r := val1.Decimal().QuoRem(val2.Decimal(), places)
+	return q, r, nil
+}
+
+// extra digits carried through series before the final rounding
+const guardDigits = 10
+
+func _abs(ast core.Expr, env *base.Env) (core.Any, error) {
+	num, err := oneNumber(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Number(num.Abs()), nil
+}
+
+func _sign(ast core.Expr, env *base.Env) (core.Any, error) {
+	num, err := oneNumber(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.NewNumber(num.Sign()), nil
+}
+
+func _floor(ast core.Expr, env *base.Env) (core.Any, error) {
+	num, err := oneNumber(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Number(num.Floor()), nil
+}
+
+func _ceil(ast core.Expr, env *base.Env) (core.Any, error) {
+	num, err := oneNumber(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Number(num.Ceil()), nil
+}
+
+// (min x y...) by compare
+func _min(ast core.Expr, env *base.Env) (core.Any, error) {
+	return extreme(ast, env, -1)
+}
+
+// (max x y...) by compare
+func _max(ast core.Expr, env *base.Env) (core.Any, error) {
+	return extreme(ast, env, 1)
+}
+
+func extreme(ast core.Expr, env *base.Env, want int) (core.Any, error) {
+	if err := minLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	args, err := evalArgs(ast[1:], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	res := args[0]
+	for _, arg := range args[1:] {
+		cmp, err := compare(arg, res)
+		if err != nil {
+			return core.Null{}, err
+		}
+		if cmp == want {
+			res = arg
+		}
+	}
+	return res, nil
+}
+
+// (round x) (round x places) (round x places :mode), mode from scope by default
+// (round m) or (round m :mode) to the currency's minor units
+func _round(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 2, 4); err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if money, ok := val.(core.Money); ok {
+		if err := rangeLen(ast, 2, 3); err != nil {
+			return core.Null{}, err
+		}
+		mode := getMathContext(env).rounding
+		if len(ast) > 2 {
+			if mode, err = evalRoundingMode(ast[2]); err != nil {
+				return core.Null{}, err
+			}
+		}
+		return roundMoney(money, mode), nil
+	}
+	num, err := numberArg(val)
+	if err != nil {
+		return core.Null{}, err
+	}
+	places := int32(0)
+	if len(ast) > 2 {
+		val, err := evalNumber(ast[2], env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		places = int32(val.Decimal().IntPart())
+	}
+	mode := getMathContext(env).rounding
+	if len(ast) > 3 {
+		if mode, err = evalRoundingMode(ast[3]); err != nil {
+			return core.Null{}, err
+		}
+	}
+	return core.Number(roundDecimal(num.Decimal(), places, mode)), nil
+}
+
+// (truncate x) or (truncate x places), toward zero
+// (truncate t :unit) to the start of the unit
+func _truncate(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 2, 3); err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if t, ok := val.(core.Instant); ok {
+		if err := exactLen(ast, 3); err != nil {
+			return core.Null{}, err
+		}
+		return truncateTime(t.Val, ast[2])
+	}
+	num, err := numberArg(val)
+	if err != nil {
+		return core.Null{}, err
+	}
+	places := int32(0)
+	if len(ast) > 2 {
+		val, err := evalNumber(ast[2], env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		places = int32(val.Decimal().IntPart())
+	}
+	return core.Number(num.Decimal().RoundDown(places)), nil
+}
+
+// (pow x y), exact for integer y >= 0
+func _pow(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	x, err := evalNumber(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	y, err := evalNumber(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	ctx := getMathContext(env)
+	res, err := pow(x.Decimal(), y.Decimal(), ctx)
+	return core.Number(res), err
+}
+
+func pow(x decimal.Decimal, y decimal.Decimal, ctx mathContext) (decimal.Decimal, error) {
+	if y.IsInteger() {
+		n := y.Abs().BigInt()
+		res, base := decimal.New(1, 0), x
+		// square and multiply
+		for i := 0; i < n.BitLen(); i++ {
+			if n.Bit(i) == 1 {
+				res = res.Mul(base)
+			}
+			if i+1 < n.BitLen() {
+				base = base.Mul(base)
+			}
+		}
+		if y.IsNegative() {
+			return divide(decimal.New(1, 0), res, ctx.precision, ctx.rounding)
+		}
+		return res, nil
+	}
+	if !x.IsPositive() {
+		return decimal.Zero, fmt.Errorf("non-integer power of non-positive %v", x)
+	}
+	wp := ctx.precision + guardDigits
+	ln, err := ln(x, wp)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	res, err := y.Mul(ln).Truncate(wp).ExpTaylor(wp)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return roundDecimal(res, ctx.precision, ctx.rounding), nil
+}
+
+func _sqrt(ast core.Expr, env *base.Env) (core.Any, error) {
+	num, err := oneNumber(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	ctx := getMathContext(env)
+	res, err := sqrt(num, ctx.precision+guardDigits)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Number(roundDecimal(res, ctx.precision, ctx.rounding)), nil
+}
+
+// newton's method from a power of ten near the root
+func sqrt(x decimal.Decimal, wp int32) (decimal.Decimal, error) {
+	if x.IsNegative() {
+		return decimal.Zero, fmt.Errorf("square root of negative %v", x)
+	}
+	if x.IsZero() {
+		return x, nil
+	}
+	digits := int32(x.NumDigits()) + x.Exponent()
+	y := decimal.New(1, digits/2)
+	two := decimal.New(2, 0)
+	epsilon := decimal.New(1, -wp)
+	for i := 0; i < 200; i++ {
+		next := y.Add(x.DivRound(y, wp)).DivRound(two, wp)
+		if next.Sub(y).Abs().LessThanOrEqual(epsilon) {
+			return next, nil
+		}
+		y = next
+	}
+	return y, nil
+}
+
+func _exp(ast core.Expr, env *base.Env) (core.Any, error) {
+	num, err := oneNumber(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	ctx := getMathContext(env)
+	res, err := num.ExpTaylor(ctx.precision + guardDigits)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Number(roundDecimal(res, ctx.precision, ctx.rounding)), nil
+}
+
+func _ln(ast core.Expr, env *base.Env) (core.Any, error) {
+	num, err := oneNumber(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	ctx := getMathContext(env)
+	res, err := ln(num, ctx.precision+guardDigits)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Number(roundDecimal(res, ctx.precision, ctx.rounding)), nil
+}
+
+func _log10(ast core.Expr, env *base.Env) (core.Any, error) {
+	num, err := oneNumber(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	ctx := getMathContext(env)
+	wp := ctx.precision + guardDigits
+	res, err := ln(num, wp)
+	if err != nil {
+		return core.Null{}, err
+	}
+	ln10, err := ln(decimal.New(10, 0), wp)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Number(roundDecimal(res.DivRound(ln10, wp), ctx.precision, ctx.rounding)), nil
+}
+
+// ln(m * 2^k) = ln(m) + k ln(2), with m in [0.75, 1.5)
+func ln(x decimal.Decimal, wp int32) (decimal.Decimal, error) {
+	if !x.IsPositive() {
+		return decimal.Zero, fmt.Errorf("logarithm of non-positive %v", x)
+	}
+	half, two := decimal.New(5, -1), decimal.New(2, 0)
+	upper, lower := decimal.New(15, -1), decimal.New(75, -2)
+	k := int64(0)
+	for x.GreaterThanOrEqual(upper) {
+		x, k = x.Mul(half), k+1
+	}
+	for x.LessThan(lower) {
+		x, k = x.Mul(two), k-1
+	}
+	one := decimal.New(1, 0)
+	res := atanh(x.Sub(one).DivRound(x.Add(one), wp), wp).Mul(two)
+	if k != 0 {
+		ln2 := atanh(one.DivRound(decimal.New(3, 0), wp), wp).Mul(two)
+		res = res.Add(ln2.Mul(decimal.New(k, 0)))
+	}
+	return res.Truncate(wp), nil
+}
+
+// z + z^3/3 + z^5/5 ..., for small |z|
+func atanh(z decimal.Decimal, wp int32) decimal.Decimal {
+	epsilon := decimal.New(1, -wp-1)
+	z2 := z.Mul(z).Truncate(wp + 1)
+	res, pow := z, z
+	for n := int64(3); ; n += 2 {
+		pow = pow.Mul(z2).Truncate(wp + 1)
+		term := pow.DivRound(decimal.New(n, 0), wp+1)
+		if term.Abs().LessThan(epsilon) {
+			return res
+		}
+		res = res.Add(term)
+	}
+}
+
+func oneNumber(ast core.Expr, env *base.Env) (decimal.Decimal, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return decimal.Zero, err
+	}
+	num, err := evalNumber(ast[1], env)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return num.Decimal(), nil
+}
@@ -0,0 +1,135 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/config"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// directories searched by import after the importing file's directory
+var SearchPath []string
+
+// module loading or loaded into an env tree, shared by concurrent imports
+type moduleLoad struct {
+	// closed once env and err are set
+	done chan struct{}
+	env  *base.Env
+	err  error
+}
+
+// context key for the paths an import chain is loading, outermost first
+type importKey struct{}
+
+func init() {
+	config.Register(config.Key{
+		Name:        "path",
+		Type:        config.String,
+		Default:     "",
+		Description: "directories searched by import, separated like PATH",
+	})
+}
+
+// (import "path.oc") or (import "path.oc" :as name)
+func _import(ast core.Expr, env *base.Env) (core.Any, error) {
+	if len(ast) != 2 && len(ast) != 4 {
+		return core.Null{}, fmt.Errorf("wanted 1 or 3 args, got %d", len(ast)-1)
+	}
+	val, err := base.Eval(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	file, ok := val.(core.String)
+	if !ok {
+		return core.Null{}, fmt.Errorf("called with non-string %#v", ast[1])
+	}
+	name := strings.TrimSuffix(filepath.Base(file.Val), filepath.Ext(file.Val))
+	if len(ast) == 4 {
+		if key, ok := core.Unwrap(ast[2]).(core.Keyword); !ok || key.Val != "as" {
+			return core.Null{}, fmt.Errorf("wanted :as, got %#v", ast[2])
+		}
+		sym, ok := ast[3].(core.Symbol)
+		if !ok {
+			return core.Null{}, fmt.Errorf("called with non-symbol %#v", ast[3])
+		}
+		name = sym.Val
+	}
+	path, err := resolveModule(file.Val, importingDir(ast))
+	if err != nil {
+		return core.Null{}, err
+	}
+	mod, err := loadModule(name, path, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	env.Set(core.NewSymbol(name, nil), mod)
+	return core.Null{}, nil
+}
+
+// directory of the file containing the import, or working directory
+func importingDir(ast core.Expr) string {
+	if pos := core.PosOf(ast[0]); pos != nil && pos.Source != nil {
+		if _, err := os.Stat(pos.Source.Name); err == nil {
+			return filepath.Dir(pos.Source.Name)
+		}
+	}
+	return "."
+}
+
+// absolute path of module file
+func resolveModule(file string, dir string) (string, error) {
+	if filepath.IsAbs(file) {
+		return file, nil
+	}
+	for _, root := range append([]string{dir}, SearchPath...) {
+		if root == "" {
+			continue
+		}
+		path := filepath.Join(root, file)
+		if _, err := os.Stat(path); err == nil {
+			return filepath.Abs(path)
+		}
+	}
+	return "", fmt.Errorf("module %q not found", file)
+}
+
+// eval module file once per env tree, in its own env under the
+// importer's builtins, context and quota
+func loadModule(name string, path string, env *base.Env) (base.Module, error) {
+	ctx := env.Context()
+	chain, _ := ctx.Value(importKey{}).([]string)
+	for _, loading := range chain {
+		if loading == path {
+			return base.Module{}, fmt.Errorf("import cycle at %q", path)
+		}
+	}
+	load := &moduleLoad{done: make(chan struct{})}
+	if val, ok := env.Modules().LoadOrStore(path, load); ok {
+		// loaded, or being loaded by another import chain
+		load = val.(*moduleLoad)
+		select {
+		case <-load.done:
+		case <-ctx.Done():
+			return base.Module{}, base.CheckContext(ctx)
+		}
+	} else {
+		func() {
+			defer close(load.done)
+			chain = append(append([]string{}, chain...), path)
+			load.env = base.NewEnvContext(context.WithValue(ctx, importKey{}, chain), env.Root())
+			if _, load.err = base.EvalFile(path, load.env); load.err != nil {
+				// a later import tries again
+				env.Modules().Delete(path)
+			}
+		}()
+	}
+	if load.err != nil {
+		return base.Module{}, load.err
+	}
+	return base.Module{Name: name, Path: path, Env: load.env}, nil
+}
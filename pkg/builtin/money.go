@@ -0,0 +1,231 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// (money 125.40 "USD") or (money 125.40 :USD)
+func _money(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	num, err := evalNumber(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	switch code := val.(type) {
+	default:
+		return core.Null{}, fmt.Errorf("called with non-currency %#v", val)
+	case core.String:
+		return core.NewMoney(num.Decimal(), code.Val)
+	case core.Keyword:
+		return core.NewMoney(num.Decimal(), code.Val)
+	}
+}
+
+func _moneyQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	val, err := oneArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	switch val.(type) {
+	default:
+		return core.Bool(false), nil
+	case core.Money:
+		return core.Bool(true), nil
+	}
+}
+
+func _amount(ast core.Expr, env *base.Env) (core.Any, error) {
+	money, err := oneMoney(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Number(money.Amount), nil
+}
+
+func _currency(ast core.Expr, env *base.Env) (core.Any, error) {
+	money, err := oneMoney(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.String{Val: money.Currency}, nil
+}
+
+// (allocate m n) even parts, or (allocate m [ratio...])
+func _allocate(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	money, err := evalMoney(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	var ratios []decimal.Decimal
+	switch arg := val.(type) {
+	default:
+		return core.Null{}, fmt.Errorf("called with non-number or non-sequence %#v", val)
+	case core.Number:
+		n := arg.Decimal().IntPart()
+		if n < 1 {
+			return core.Null{}, fmt.Errorf("cannot allocate into %v parts", arg)
+		}
+		// one part each
+		if err := env.Alloc(int(n)); err != nil {
+			return core.Null{}, err
+		}
+		ratios = make([]decimal.Decimal, n)
+		for i := range ratios {
+			ratios[i] = core.One.Decimal()
+		}
+	case core.Seq:
+		items, err := items(env, arg)
+		if err != nil {
+			return core.Null{}, err
+		}
+		for _, item := range items {
+			num, ok := item.(core.Number)
+			if !ok {
+				return core.Null{}, fmt.Errorf("called with non-number ratio %#v", item)
+			}
+			ratios = append(ratios, num.Decimal())
+		}
+		if err := env.Alloc(len(ratios)); err != nil {
+			return core.Null{}, err
+		}
+	}
+	parts, err := money.Allocate(ratios)
+	if err != nil {
+		return core.Null{}, err
+	}
+	res := make(core.Vector, len(parts))
+	for i, part := range parts {
+		res[i] = part
+	}
+	return res, nil
+}
+
+// (add m...) or (sub m...), all in one currency
+func addMoney(args []core.Any, sub bool) (core.Any, error) {
+	res, err := moneyArg(args[0])
+	if err != nil {
+		return core.Null{}, err
+	}
+	if sub && len(args) == 1 {
+		return core.Money{Amount: res.Amount.Neg(), Currency: res.Currency}, nil
+	}
+	for _, arg := range args[1:] {
+		money, err := moneyArg(arg)
+		if err != nil {
+			return core.Null{}, err
+		}
+		if sub {
+			res, err = res.Sub(money)
+		} else {
+			res, err = res.Add(money)
+		}
+		if err != nil {
+			return core.Null{}, err
+		}
+	}
+	return res, nil
+}
+
+// (mul m n...) or (mul n... m), a single money scaled by numbers
+func mulMoney(args []core.Any) (core.Any, error) {
+	var res *core.Money
+	amount := core.One.Decimal()
+	for _, arg := range args {
+		switch val := arg.(type) {
+		default:
+			return core.Null{}, fmt.Errorf("called with non-number %#v", arg)
+		case core.Money:
+			if res != nil {
+				return core.Null{}, fmt.Errorf("cannot multiply money by money")
+			}
+			res = &val
+			amount = amount.Mul(val.Amount)
+		case core.Number:
+			amount = amount.Mul(val.Decimal())
+		}
+	}
+	return core.Money{Amount: amount, Currency: res.Currency}, nil
+}
+
+// (div* m n...) is money, (div* m m) a ratio in one currency
+func divMoney(args []core.Any, ctx mathContext) (core.Any, error) {
+	money, err := moneyArg(args[0])
+	if err != nil {
+		return core.Null{}, err
+	}
+	if len(args) == 2 {
+		if other, ok := args[1].(core.Money); ok {
+			if _, err := money.Cmp(other); err != nil {
+				return core.Null{}, err
+			}
+			res, err := divide(money.Amount, other.Amount, ctx.precision, ctx.rounding)
+			return core.Number(res), err
+		}
+	}
+	res := money.Amount
+	for _, arg := range args[1:] {
+		num, ok := arg.(core.Number)
+		if !ok {
+			return core.Null{}, fmt.Errorf("called with non-number %#v", arg)
+		}
+		if res, err = divide(res, num.Decimal(), ctx.precision, ctx.rounding); err != nil {
+			return core.Null{}, err
+		}
+	}
+	return core.Money{Amount: res, Currency: money.Currency}, nil
+}
+
+// round to the currency's minor units
+func roundMoney(money core.Money, mode string) core.Money {
+	units, _ := core.MinorUnits(money.Currency)
+	return core.Money{Amount: roundDecimal(money.Amount, units, mode), Currency: money.Currency}
+}
+
+func hasMoney(args []core.Any) bool {
+	for _, arg := range args {
+		if _, ok := arg.(core.Money); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func moneyArg(val core.Any) (core.Money, error) {
+	money, ok := val.(core.Money)
+	if !ok {
+		return core.Money{}, fmt.Errorf("called with non-money %#v", val)
+	}
+	return money, nil
+}
+
+func evalMoney(ast core.Any, env *base.Env) (core.Money, error) {
+	val, err := base.Eval(ast, env)
+	if err != nil {
+		return core.Money{}, err
+	}
+	return moneyArg(val)
+}
+
+func oneMoney(ast core.Expr, env *base.Env) (core.Money, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Money{}, err
+	}
+	return evalMoney(ast[1], env)
+}
@@ -0,0 +1,183 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/config"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+func init() {
+	config.Register(config.Key{
+		Name:        "pool-size",
+		Type:        config.Int,
+		Default:     0,
+		Description: "goroutines shared by pmap and pfor, 0 for one per CPU",
+		Validate: func(value interface{}) error {
+			if value.(int64) < 0 {
+				return fmt.Errorf("must not be negative")
+			}
+			return nil
+		},
+	})
+}
+
+// process-wide slots for pmap and pfor goroutines, sized on first use
+var pool struct {
+	once  sync.Once
+	slots chan struct{}
+}
+
+func poolSlots() chan struct{} {
+	pool.once.Do(func() {
+		size := int(config.GetInt("pool-size"))
+		if size <= 0 {
+			size = runtime.NumCPU()
+		}
+		pool.slots = make(chan struct{}, size)
+	})
+	return pool.slots
+}
+
+// (pmap f coll) or (pmap f coll :workers n) like map, in parallel
+func _pmap(ast core.Expr, env *base.Env) (core.Any, error) {
+	if len(ast) != 3 && len(ast) != 5 {
+		return core.Null{}, fmt.Errorf("wanted 2 or 4 args, got %d", len(ast)-1)
+	}
+	fn, err := evalFunc(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	items, err := evalItems(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	workers, err := workersOption(ast[3:], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if err := env.Alloc(len(items)); err != nil {
+		return core.Null{}, err
+	}
+	return parallel(len(items), workers, env, func(i int, local *base.Env) (core.Any, error) {
+		return call(fn, ast[1], local, items[i]).GetContext(local.Context())
+	})
+}
+
+// (pfor [x coll] body) or (pfor [x coll :workers n] body),
+// body for each item in parallel
+func _pfor(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	binding, ok := core.Unwrap(ast[1]).(core.Vector)
+	if !ok || (len(binding) != 2 && len(binding) != 4) {
+		return core.Null{}, fmt.Errorf("wanted [x coll] binding, got %#v", ast[1])
+	}
+	if err := checkPattern(binding[0]); err != nil {
+		return core.Null{}, err
+	}
+	items, err := evalItems(binding[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	workers, err := workersOption(binding[2:], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if err := env.Alloc(len(items)); err != nil {
+		return core.Null{}, err
+	}
+	return parallel(len(items), workers, env, func(i int, local *base.Env) (core.Any, error) {
+		item := items[i]
+		scope := base.NewEnv(local)
+		bindPattern(binding[0], func() (core.Any, error) {
+			return item, nil
+		}, scope)
+		return base.Eval(ast[2], scope)
+	})
+}
+
+// none, or :workers n
+func workersOption(opts []core.Any, env *base.Env) (int, error) {
+	if len(opts) == 0 {
+		return cap(poolSlots()), nil
+	}
+	if key, ok := core.Unwrap(opts[0]).(core.Keyword); !ok || key.Val != "workers" {
+		return 0, fmt.Errorf("wanted :workers, got %#v", opts[0])
+	}
+	num, err := evalNumber(opts[1], env)
+	if err != nil {
+		return 0, err
+	}
+	n := num.Decimal().IntPart()
+	if n < 1 {
+		return 0, fmt.Errorf("called with %v workers", num)
+	}
+	return int(n), nil
+}
+
+// results of task 0 to n-1 in order, at most workers at a time; tasks
+// run on pool goroutines, or on the caller's when the pool is busy so
+// nested calls cannot deadlock, and the first error cancels the rest
+func parallel(n int, workers int, env *base.Env, task func(i int, local *base.Env) (core.Any, error)) (core.Any, error) {
+	ctx, cancel := context.WithCancel(env.Context())
+	defer cancel()
+	local := env.WithContext(ctx)
+	res := make(core.Vector, n)
+	var first error
+	var once sync.Once
+	run := func(i int, local *base.Env) {
+		// a panic must not take down the process from a goroutine
+		val, err := base.Future(func() (core.Any, error) {
+			return task(i, local)
+		}).Recover()()
+		if err != nil {
+			once.Do(func() {
+				first = err
+				cancel()
+			})
+			return
+		}
+		res[i] = val
+	}
+	slots := poolSlots()
+	limit := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+loop:
+	for i := 0; i < n; i++ {
+		select {
+		case limit <- struct{}{}:
+		case <-ctx.Done():
+			break loop
+		}
+		select {
+		case slots <- struct{}{}:
+			wg.Add(1)
+			go func(i int) {
+				defer func() {
+					<-slots
+					<-limit
+					wg.Done()
+				}()
+				run(i, env.Task(ctx))
+			}(i)
+		default:
+			// pool is busy, run on the caller
+			run(i, local)
+			<-limit
+		}
+	}
+	wg.Wait()
+	if first != nil {
+		return core.Null{}, first
+	}
+	if err := env.Check(); err != nil {
+		return core.Null{}, err
+	}
+	return res, nil
+}
@@ -0,0 +1,103 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// parameter with default value
+type optParam struct {
+	pattern core.Any
+	def     core.Any
+}
+
+// parsed function parameters: [a (b default) & rest] or [a & {"key": k}]
+type signature struct {
+	name     string
+	params   core.Vector
+	required []core.Any
+	optional []optParam
+	rest     core.Any
+}
+
+func parseSignature(name string, params core.Vector) (*signature, error) {
+	sig := &signature{name: name, params: params}
+	for i, param := range params {
+		param = core.Unwrap(param)
+		if sym, ok := param.(core.Symbol); ok && sym.Val == "&" {
+			if i != len(params)-2 {
+				return nil, fmt.Errorf("bind expression wanted one pattern after &")
+			}
+			sig.rest = core.Unwrap(params[i+1])
+			return sig, checkPattern(sig.rest)
+		}
+		switch arg := param.(type) {
+		default:
+			if len(sig.optional) != 0 {
+				return nil, fmt.Errorf("required parameter %#v after optional", param)
+			}
+			if err := checkPattern(arg); err != nil {
+				return nil, err
+			}
+			sig.required = append(sig.required, arg)
+		case core.Expr:
+			if err := checkItemPattern(arg); err != nil {
+				return nil, err
+			}
+			sig.optional = append(sig.optional, optParam{pattern: arg[0], def: arg[1]})
+		}
+	}
+	return sig, nil
+}
+
+func (sig *signature) arityError(args core.Expr) error {
+	return fmt.Errorf("%s wanted %v, got %d arg(s)", sig.name, sig.params, len(args)-1)
+}
+
+// bind args lazily: args eval in outer, defaults eval in local
+func (sig *signature) bind(args core.Expr, outer *base.Env, local *base.Env) error {
+	argc := len(args) - 1
+	positional := len(sig.required) + len(sig.optional)
+	// trailing hash destructured as keyword args
+	_, keywords := sig.rest.(core.Hash)
+	switch {
+	case argc < len(sig.required):
+		return sig.arityError(args)
+	case keywords:
+		if argc > positional+1 {
+			return sig.arityError(args)
+		}
+	case sig.rest != nil:
+		break
+	case argc > positional:
+		return sig.arityError(args)
+	}
+	for i, pattern := range sig.required {
+		bindPattern(pattern, base.FutureEval(args[i+1], outer), local)
+	}
+	for i, opt := range sig.optional {
+		n := len(sig.required) + i + 1
+		if n <= argc {
+			bindPattern(opt.pattern, base.FutureEval(args[n], outer), local)
+		} else {
+			bindPattern(opt.pattern, base.FutureEval(opt.def, local), local)
+		}
+	}
+	var more core.Expr
+	if argc > positional {
+		more = args[positional+1:]
+	}
+	switch {
+	case sig.rest == nil:
+		break
+	case keywords && len(more) == 0:
+		bindPattern(sig.rest, base.FutureEval(core.Hash{}, local), local)
+	case keywords:
+		bindPattern(sig.rest, base.FutureEval(more[0], outer), local)
+	default:
+		bindPattern(sig.rest, base.FutureEval(core.Vector(more), outer), local)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// (async expr) promise of expr evaluated in a goroutine,
+// a symbol or vector of symbols also resolves their lazy bindings async
+func _async(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	switch arg := core.Unwrap(ast[1]).(type) {
+	case core.Symbol:
+		if err := env.Async(arg); err != nil {
+			return core.Null{}, err
+		}
+	case core.Vector:
+		for _, item := range arg {
+			if sym, ok := item.(core.Symbol); ok {
+				if err := env.Async(sym); err != nil {
+					return core.Null{}, err
+				}
+			}
+		}
+	}
+	return base.NewPromise(ast[1], env), nil
+}
+
+func _promiseQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	val, err := oneArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	_, ok := val.(base.Promise)
+	return core.Bool(ok), nil
+}
+
+// (await p) value of p, or raise its error
+func _await(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	p, err := evalPromise(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	val, err := p.Await(env.Context())
+	return val, waitError(err, env)
+}
+
+// (await-all [p...]) values in order, or the first error
+func _awaitAll(ast core.Expr, env *base.Env) (core.Any, error) {
+	ps, err := promisesArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	vals, err := base.AwaitAll(env.Context(), ps)
+	if err != nil {
+		return core.Null{}, waitError(err, env)
+	}
+	return core.Vector(vals), nil
+}
+
+// (race [p...]) first to settle, the rest are cancelled
+func _race(ast core.Expr, env *base.Env) (core.Any, error) {
+	ps, err := promisesArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Race(env.Context(), ps)
+	return val, waitError(err, env)
+}
+
+// (cancel p) stop evaluation of p, awaiting it then fails
+func _cancel(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	p, err := evalPromise(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	p.Cancel()
+	return core.Null{}, nil
+}
+
+// (with-timeout t body...) body cancelled after seconds or a duration
+func _withTimeout(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := minLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	dur, err := evalDuration(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	// like context.WithTimeout, but left uncancelled once the body returns:
+	// lazy values and promises from the body stop with the caller instead
+	ctx, cancel := context.WithCancel(env.Context())
+	deadline := time.AfterFunc(dur, cancel)
+	defer deadline.Stop()
+	body := cons(core.NewSymbol("do", nil), ast[2:])
+	// resolved here, before the deadline stops
+	val, err := base.Eval(body, env.WithContext(ctx))
+	if base.Cancelled(err) && env.Context().Err() == nil {
+		// catchable, unlike cancellation of the caller
+		return core.Null{}, fmt.Errorf("timed out after %v", dur)
+	}
+	return val, err
+}
+
+func promisesArg(ast core.Expr, env *base.Env) ([]base.Promise, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return nil, err
+	}
+	items, err := evalItems(ast[1], env)
+	if err != nil {
+		return nil, err
+	}
+	ps := make([]base.Promise, len(items))
+	for i, item := range items {
+		p, ok := item.(base.Promise)
+		if !ok {
+			return nil, fmt.Errorf("called with non-promise %#v", item)
+		}
+		ps[i] = p
+	}
+	return ps, nil
+}
+
+func evalPromise(ast core.Any, env *base.Env) (base.Promise, error) {
+	val, err := base.Eval(ast, env)
+	if err != nil {
+		return base.Promise{}, err
+	}
+	p, ok := val.(base.Promise)
+	if !ok {
+		return base.Promise{}, fmt.Errorf("called with non-promise %#v", val)
+	}
+	return p, nil
+}
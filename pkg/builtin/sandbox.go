@@ -0,0 +1,51 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/starlight/ocelot/pkg/base"
+)
+
+// builtins a sandbox can leave out, by group
+var Groups = map[string][]string{
+	// files, output and the host process
+	"io": {"prn", "import", "calendar", "config", "on-shutdown"},
+	// goroutines and blocking
+	"async": {
+		"async", "wait", "await", "await-all", "race", "cancel", "with-timeout",
+		"chan", "put!", "take!", "close!", "timeout", "alts", "select",
+		"pmap", "pfor",
+		"spawn", "spawn-link", "self", "send", "receive", "link", "monitor",
+		"kill", "alive?",
+	},
+	// code from values
+	"eval": {"eval", "parse"},
+}
+
+type Sandbox struct {
+	base.Limits
+	// names of Groups to leave out
+	Omit []string
+}
+
+// builtins limited by sandbox, release stops its clock
+func SandboxEnv(ctx context.Context, sandbox Sandbox) (*base.Env, context.CancelFunc, error) {
+	omit := make(map[string]bool)
+	for _, group := range sandbox.Omit {
+		names, ok := Groups[group]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown builtin group %q", group)
+		}
+		for _, name := range names {
+			omit[name] = true
+		}
+	}
+	env, release := base.NewSandbox(ctx, sandbox.Limits)
+	for sym, val := range Builtin {
+		if !omit[sym] {
+			env.SetFunc(sym, val)
+		}
+	}
+	return env, release, nil
+}
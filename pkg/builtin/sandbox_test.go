@@ -0,0 +1,72 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/starlight/ocelot/pkg/base"
+)
+
+func sandboxEnv(t *testing.T, limits base.Limits) *base.Env {
+	t.Helper()
+	env, release, err := SandboxEnv(context.Background(), Sandbox{Limits: limits})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(release)
+	return env
+}
+
+// err stopped evaluation at the quota named
+func quotaExceeded(err error, name string) bool {
+	var quota *base.QuotaError
+	return errors.As(err, &quota) && quota.Quota == name
+}
+
+func TestAllocateQuota(t *testing.T) {
+	env := sandboxEnv(t, base.Limits{Alloc: 1000})
+	if _, err := base.EvalStr(`(allocate (money 100 "USD") 100000)`, env); !quotaExceeded(err, "alloc") {
+		t.Errorf("got %v, wanted alloc quota", err)
+	}
+}
+
+func TestNegativeChanKeepsQuota(t *testing.T) {
+	env := sandboxEnv(t, base.Limits{Alloc: 100})
+	if _, err := base.EvalStr(`(try (chan -1000000000) (catch [e] e))`, env); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := base.EvalStr(`(chan 1000)`, env); !quotaExceeded(err, "alloc") {
+		t.Errorf("got %v, wanted alloc quota", err)
+	}
+}
+
+func TestDepthQuotaPerTask(t *testing.T) {
+	env := sandboxEnv(t, base.Limits{Depth: 200})
+	in := `
+		(defn! deep [n] (if (equal? n 0) (do (wait 0.01) 0) (add 1 (deep (sub n 1)))))
+		(await-all [(async (deep 30)) (async (deep 30)) (async (deep 30)) (async (deep 30))])`
+	if _, err := base.EvalStr(in, env); err != nil {
+		t.Errorf("parallel calls within the limit: %v", err)
+	}
+	if _, err := base.EvalStr(`(deep 500)`, env); !quotaExceeded(err, "depth") {
+		t.Errorf("got %v, wanted depth quota", err)
+	}
+}
+
+func TestSandboxMailboxes(t *testing.T) {
+	a := sandboxEnv(t, base.Limits{Alloc: 1000})
+	b := sandboxEnv(t, base.Limits{})
+	if _, err := base.EvalStr(`(send (self) :from-a)`, a); err != nil {
+		t.Fatal(err)
+	}
+	val, err := evalLast(`(receive msg msg :after 0.01 :empty)`, b)
+	if err != nil || val.String() != ":empty" {
+		t.Errorf("got %v, %v, wanted b's mailbox empty", val, err)
+	}
+	in := `(defn! flood [n] (if (equal? n 0) :done (do (send (self) n) (flood (sub n 1)))))
+		(flood 5000)`
+	if _, err := base.EvalStr(in, a); !quotaExceeded(err, "alloc") {
+		t.Errorf("got %v, wanted alloc quota", err)
+	}
+}
@@ -0,0 +1,248 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// (range) (range end) (range start end) (range start end step)
+func _range(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 1, 4); err != nil {
+		return core.Null{}, err
+	}
+	nums := make([]decimal.Decimal, len(ast)-1)
+	for i, item := range ast[1:] {
+		val, err := evalNumber(item, env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		nums[i] = val.Decimal()
+	}
+	start, step := core.Zero.Decimal(), core.One.Decimal()
+	var end *decimal.Decimal
+	switch len(nums) {
+	case 1:
+		end = &nums[0]
+	case 3:
+		step = nums[2]
+		fallthrough
+	case 2:
+		start, end = nums[0], &nums[1]
+	}
+	if step.IsZero() {
+		return core.Null{}, fmt.Errorf("called with zero step")
+	}
+	var from func(num decimal.Decimal) core.Seq
+	from = func(num decimal.Decimal) core.Seq {
+		return core.NewLazySeq(func() (core.Seq, error) {
+			if end != nil && (step.IsPositive() && num.GreaterThanOrEqual(*end) ||
+				step.IsNegative() && num.LessThanOrEqual(*end)) {
+				return core.Vector{}, nil
+			}
+			return core.Cons{First: core.Number(num), Rest: from(num.Add(step))}, nil
+		})
+	}
+	return from(start), nil
+}
+
+// (iterate f x): x, (f x), (f (f x)), ...
+func _iterate(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	fn, err := evalFunc(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	var from func(val core.Any) core.Seq
+	from = func(val core.Any) core.Seq {
+		rest := core.NewLazySeq(func() (core.Seq, error) {
+			next, err := call(fn, ast[1], env, val).Get()
+			if err != nil {
+				return nil, err
+			}
+			return from(next), nil
+		})
+		return core.Cons{First: val, Rest: rest}
+	}
+	return from(val), nil
+}
+
+// (repeat x) or (repeat n x)
+func _repeat(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 2, 3); err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[len(ast)-1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if len(ast) == 3 {
+		num, err := evalNumber(ast[1], env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		n := num.Decimal().IntPart()
+		if n < 0 {
+			return core.Null{}, fmt.Errorf("called with negative count %v", num)
+		}
+		if err := env.Alloc(int(n)); err != nil {
+			return core.Null{}, err
+		}
+		res := make(core.Vector, n)
+		for i := range res {
+			res[i] = val
+		}
+		return res, nil
+	}
+	var seq core.Seq
+	seq = core.NewLazySeq(func() (core.Seq, error) {
+		return core.Cons{First: val, Rest: seq}, nil
+	})
+	return seq, nil
+}
+
+// (take n seq)
+func _take(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	num, err := evalNumber(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	seq, err := evalSeq(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	var take func(n int64, seq core.Seq) core.Seq
+	take = func(n int64, seq core.Seq) core.Seq {
+		return core.NewLazySeq(func() (core.Seq, error) {
+			if n <= 0 {
+				return core.Vector{}, nil
+			}
+			first, rest, ok, err := seq.Next()
+			if err != nil || !ok {
+				return core.Vector{}, err
+			}
+			return core.Cons{First: first, Rest: take(n-1, rest)}, nil
+		})
+	}
+	return take(num.Decimal().IntPart(), seq), nil
+}
+
+// (drop n seq)
+func _drop(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	num, err := evalNumber(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	seq, err := evalSeq(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	n := num.Decimal().IntPart()
+	return core.NewLazySeq(func() (core.Seq, error) {
+		for i := int64(0); i < n; i++ {
+			_, rest, ok, err := seq.Next()
+			if err != nil || !ok {
+				return core.Vector{}, err
+			}
+			seq = rest
+		}
+		return seq, nil
+	}), nil
+}
+
+// (take-while pred seq)
+func _takeWhile(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	fn, err := evalFunc(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	seq, err := evalSeq(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	var takeWhile func(seq core.Seq) core.Seq
+	takeWhile = func(seq core.Seq) core.Seq {
+		return core.NewLazySeq(func() (core.Seq, error) {
+			first, rest, ok, err := seq.Next()
+			if err != nil || !ok {
+				return core.Vector{}, err
+			}
+			test, err := call(fn, ast[1], env, first).Get()
+			if err != nil || !truthy(test) {
+				return core.Vector{}, err
+			}
+			return core.Cons{First: first, Rest: takeWhile(rest)}, nil
+		})
+	}
+	return takeWhile(seq), nil
+}
+
+// (lazy-cat seq...), each seq is only evaluated when reached
+func _lazyCat(ast core.Expr, env *base.Env) (core.Any, error) {
+	var cat func(args core.Expr, seq core.Seq) core.Seq
+	cat = func(args core.Expr, seq core.Seq) core.Seq {
+		return core.NewLazySeq(func() (core.Seq, error) {
+			for {
+				if seq != nil {
+					first, rest, ok, err := seq.Next()
+					if err != nil {
+						return nil, err
+					}
+					if ok {
+						return core.Cons{First: first, Rest: cat(args, rest)}, nil
+					}
+				}
+				if len(args) == 0 {
+					return core.Vector{}, nil
+				}
+				next, err := evalSeq(args[0], env)
+				if err != nil {
+					return nil, err
+				}
+				seq, args = next, args[1:]
+			}
+		})
+	}
+	return cat(ast[1:], nil), nil
+}
+
+// lazily map fn over seq
+func mapSeq(fn base.Func, head core.Any, env *base.Env, seq core.Seq) core.Seq {
+	return core.NewLazySeq(func() (core.Seq, error) {
+		first, rest, ok, err := seq.Next()
+		if err != nil || !ok {
+			return core.Vector{}, err
+		}
+		val, err := call(fn, head, env, first).Get()
+		if err != nil {
+			return nil, err
+		}
+		return core.Cons{First: val, Rest: mapSeq(fn, head, env, rest)}, nil
+	})
+}
+
+func _seqQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	val, err := oneArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	_, ok := val.(core.Seq)
+	return core.Bool(ok), nil
+}
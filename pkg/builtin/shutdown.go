@@ -0,0 +1,75 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/config"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+type shutdownHook struct {
+	fn   base.Func
+	head core.Any
+	env  *base.Env
+}
+
+// registered by on-shutdown, run once
+var shutdownHooks = struct {
+	sync.Mutex
+	hooks []shutdownHook
+}{}
+
+func init() {
+	config.Register(config.Key{
+		Name:        "shutdown-timeout",
+		Type:        config.Duration,
+		Default:     "10s",
+		Description: "time given to on-shutdown hooks before they are cancelled",
+		Validate: func(value interface{}) error {
+			if value.(time.Duration) <= 0 {
+				return fmt.Errorf("must be positive")
+			}
+			return nil
+		},
+	})
+}
+
+// (on-shutdown f) call f with no args when the program exits
+func _onShutdown(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	fn, err := evalFunc(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	shutdownHooks.Lock()
+	defer shutdownHooks.Unlock()
+	shutdownHooks.hooks = append(shutdownHooks.hooks, shutdownHook{fn, ast[1], env})
+	return core.Null{}, nil
+}
+
+// run on-shutdown hooks, last registered first, with a fresh context
+func RunShutdownHooks() {
+	shutdownHooks.Lock()
+	hooks := shutdownHooks.hooks
+	shutdownHooks.hooks = nil
+	shutdownHooks.Unlock()
+	if len(hooks) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), config.GetDuration("shutdown-timeout"))
+	defer cancel()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook := hooks[i]
+		env := base.NewEnvContext(ctx, hook.env)
+		if _, err := call(hook.fn, hook.head, env).Get(); err != nil {
+			fmt.Fprintln(os.Stderr, "on-shutdown:", err)
+		}
+	}
+}
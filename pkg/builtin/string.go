@@ -0,0 +1,352 @@
+package builtin
+
+import (
+	"container/list"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// (str arg...) concatenate printed args
+func _str(ast core.Expr, env *base.Env) (core.Any, error) {
+	var sb strings.Builder
+	for _, item := range ast[1:] {
+		val, err := base.Eval(item, env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		if err := core.PrintError(val); err != nil {
+			return core.Null{}, err
+		}
+		sb.WriteString(val.String())
+	}
+	return core.String{Val: sb.String()}, nil
+}
+
+// (format "template" arg...)
+func _format(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := minLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	tmpl, err := evalString(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	args, err := evalArgs(ast[2:], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if err := core.PrintError(core.Vector(args)); err != nil {
+		return core.Null{}, err
+	}
+	str, err := format(tmpl.Val, args)
+	return core.String{Val: str}, err
+}
+
+// (split s sep) or (split s sep n)
+func _split(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 3, 4); err != nil {
+		return core.Null{}, err
+	}
+	str, err := evalString(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	sep, err := evalString(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	n := -1
+	if len(ast) == 4 {
+		num, err := evalNumber(ast[3], env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		n = int(num.Decimal().IntPart())
+	}
+	return alloc(env, stringVector(strings.SplitN(str.Val, sep.Val, n)))
+}
+
+// (join seq) or (join sep seq)
+func _join(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 2, 3); err != nil {
+		return core.Null{}, err
+	}
+	sep := core.String{}
+	if len(ast) == 3 {
+		var err error
+		if sep, err = evalString(ast[1], env); err != nil {
+			return core.Null{}, err
+		}
+	}
+	items, err := evalItems(ast[len(ast)-1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	strs := make([]string, len(items))
+	for i, item := range items {
+		if err := core.PrintError(item); err != nil {
+			return core.Null{}, err
+		}
+		strs[i] = item.String()
+	}
+	return core.String{Val: strings.Join(strs, sep.Val)}, nil
+}
+
+// (trim s) whitespace, or (trim s cutset)
+func _trim(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 2, 3); err != nil {
+		return core.Null{}, err
+	}
+	str, err := evalString(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if len(ast) == 3 {
+		cutset, err := evalString(ast[2], env)
+		if err != nil {
+			return core.Null{}, err
+		}
+		return core.String{Val: strings.Trim(str.Val, cutset.Val)}, nil
+	}
+	return core.String{Val: strings.TrimSpace(str.Val)}, nil
+}
+
+func _upper(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	str, err := evalString(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.String{Val: strings.ToUpper(str.Val)}, nil
+}
+
+func _lower(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	str, err := evalString(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.String{Val: strings.ToLower(str.Val)}, nil
+}
+
+// (replace s old new) all occurrences
+func _replace(ast core.Expr, env *base.Env) (core.Any, error) {
+	strs, err := evalStrings(ast, 3, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.String{Val: strings.ReplaceAll(strs[0], strs[1], strs[2])}, nil
+}
+
+// (substring s start) or (substring s start end), indexed by rune
+func _substring(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 3, 4); err != nil {
+		return core.Null{}, err
+	}
+	str, err := evalString(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	runes := []rune(str.Val)
+	start, end, err := evalBounds(ast[2:], len(runes), env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.String{Val: string(runes[start:end])}, nil
+}
+
+func _startsWithQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	strs, err := evalStrings(ast, 2, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Bool(strings.HasPrefix(strs[0], strs[1])), nil
+}
+
+func _endsWithQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	strs, err := evalStrings(ast, 2, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Bool(strings.HasSuffix(strs[0], strs[1])), nil
+}
+
+// (index-of s sub) rune index, or null
+func _indexOf(ast core.Expr, env *base.Env) (core.Any, error) {
+	strs, err := evalStrings(ast, 2, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	i := strings.Index(strs[0], strs[1])
+	if i < 0 {
+		return core.Null{}, nil
+	}
+	return core.NewNumber(utf8.RuneCountInString(strs[0][:i])), nil
+}
+
+// user-perceived characters, as extended grapheme clusters (UAX #29)
+func _chars(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	str, err := evalString(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	res := core.Vector{}
+	for g := uniseg.NewGraphemes(str.Val); g.Next(); {
+		res = append(res, core.String{Val: g.Str()})
+	}
+	return alloc(env, res)
+}
+
+// code points as numbers
+func _runes(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	str, err := evalString(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	res := core.Vector{}
+	for _, r := range str.Val {
+		res = append(res, core.NewNumber(int(r)))
+	}
+	return alloc(env, res)
+}
+
+// (re-find pattern s) first match, [match group...] when pattern has groups
+func _reFind(ast core.Expr, env *base.Env) (core.Any, error) {
+	re, str, err := evalRegexp(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return reMatch(re, re.FindStringSubmatch(str)), nil
+}
+
+// (re-matches pattern s) like re-find, but the whole string must match
+func _reMatches(ast core.Expr, env *base.Env) (core.Any, error) {
+	re, str, err := evalRegexp(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	anchored, err := compileRegexp(`^(?:` + re.String() + `)$`)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return reMatch(anchored, anchored.FindStringSubmatch(str)), nil
+}
+
+// (re-replace s pattern replacement) with $1 group expansion
+func _reReplace(ast core.Expr, env *base.Env) (core.Any, error) {
+	strs, err := evalStrings(ast, 3, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	re, err := compileRegexp(strs[1])
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.String{Val: re.ReplaceAllString(strs[0], strs[2])}, nil
+}
+
+// (re-split s pattern)
+func _reSplit(ast core.Expr, env *base.Env) (core.Any, error) {
+	strs, err := evalStrings(ast, 2, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	re, err := compileRegexp(strs[1])
+	if err != nil {
+		return core.Null{}, err
+	}
+	return alloc(env, stringVector(re.Split(strs[0], -1)))
+}
+
+// (re-x pattern s)
+func evalRegexp(ast core.Expr, env *base.Env) (*regexp.Regexp, string, error) {
+	strs, err := evalStrings(ast, 2, env)
+	if err != nil {
+		return nil, "", err
+	}
+	re, err := compileRegexp(strs[0])
+	return re, strs[1], err
+}
+
+func reMatch(re *regexp.Regexp, match []string) core.Any {
+	if match == nil {
+		return core.Null{}
+	}
+	if re.NumSubexp() == 0 {
+		return core.String{Val: match[0]}
+	}
+	return stringVector(match)
+}
+
+// recently compiled patterns by source, least recently used dropped first
+var regexps = struct {
+	sync.Mutex
+	order     *list.List
+	byPattern map[string]*list.Element
+}{order: list.New(), byPattern: make(map[string]*list.Element)}
+
+const maxRegexps = 256
+
+func compileRegexp(pattern string) (*regexp.Regexp, error) {
+	regexps.Lock()
+	if elem, ok := regexps.byPattern[pattern]; ok {
+		regexps.order.MoveToFront(elem)
+		regexps.Unlock()
+		return elem.Value.(*regexp.Regexp), nil
+	}
+	regexps.Unlock()
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexps.Lock()
+	defer regexps.Unlock()
+	if _, ok := regexps.byPattern[pattern]; !ok {
+		regexps.byPattern[pattern] = regexps.order.PushFront(re)
+		if regexps.order.Len() > maxRegexps {
+			oldest := regexps.order.Remove(regexps.order.Back()).(*regexp.Regexp)
+			delete(regexps.byPattern, oldest.String())
+		}
+	}
+	return re, nil
+}
+
+func stringVector(strs []string) core.Vector {
+	res := make(core.Vector, len(strs))
+	for i, str := range strs {
+		res[i] = core.String{Val: str}
+	}
+	return res
+}
+
+// exactly num string args
+func evalStrings(ast core.Expr, num int, env *base.Env) ([]string, error) {
+	if err := exactLen(ast, num+1); err != nil {
+		return nil, err
+	}
+	res := make([]string, num)
+	for i, item := range ast[1:] {
+		str, err := evalString(item, env)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = str.Val
+	}
+	return res, nil
+}
@@ -0,0 +1,33 @@
+package builtin
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCharsGraphemes(t *testing.T) {
+	env := testEnv(t, ``)
+	for in, want := range map[string]string{
+		"a🇺🇸🇬🇧":   "3",
+		"각é":   "2",
+		"👩‍👩‍👧👍🏽": "2",
+	} {
+		val, err := evalLast(fmt.Sprintf(`(count (chars %q))`, in), env)
+		if err != nil || val.String() != want {
+			t.Errorf("%q: got %v, %v, wanted %s", in, val, err, want)
+		}
+	}
+}
+
+func TestRegexpCacheBounded(t *testing.T) {
+	for i := 0; i < 2*maxRegexps; i++ {
+		if _, err := compileRegexp(fmt.Sprintf("a%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	regexps.Lock()
+	defer regexps.Unlock()
+	if len(regexps.byPattern) != maxRegexps || regexps.order.Len() != maxRegexps {
+		t.Errorf("cached %d patterns, wanted %d", len(regexps.byPattern), maxRegexps)
+	}
+}
@@ -0,0 +1,373 @@
+package builtin
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // zones without system zoneinfo
+
+	"github.com/shopspring/decimal"
+	"github.com/starlight/ocelot/pkg/base"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+func _now(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 1); err != nil {
+		return core.Null{}, err
+	}
+	return core.Instant{Val: time.Now().UTC()}, nil
+}
+
+// (instant "2024-01-15T14:30:00Z") RFC 3339,
+// or (instant "2024-01-15T09:30:00" "America/New_York") local time in zone
+func _instant(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 2, 3); err != nil {
+		return core.Null{}, err
+	}
+	str, err := evalString(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	if len(ast) == 2 {
+		t, err := time.Parse(time.RFC3339Nano, str.Val)
+		if err != nil {
+			return core.Null{}, err
+		}
+		return core.Instant{Val: t}, nil
+	}
+	loc, err := evalLocation(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	t, err := time.ParseInLocation("2006-01-02T15:04:05.999999999", str.Val, loc)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Instant{Val: t}, nil
+}
+
+// (date "2024-01-15") (date 2024 1 15) or (date instant) in the instant's zone
+func _date(ast core.Expr, env *base.Env) (core.Any, error) {
+	if len(ast) == 4 {
+		nums := make([]int, 3)
+		for i, item := range ast[1:] {
+			num, err := evalNumber(item, env)
+			if err != nil {
+				return core.Null{}, err
+			}
+			nums[i] = int(num.Decimal().IntPart())
+		}
+		return core.NewDate(nums[0], time.Month(nums[1]), nums[2]), nil
+	}
+	val, err := oneArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	switch arg := val.(type) {
+	default:
+		return core.Null{}, fmt.Errorf("called with non-string or non-instant %#v", val)
+	case core.String:
+		t, err := time.Parse("2006-01-02", arg.Val)
+		if err != nil {
+			return core.Null{}, err
+		}
+		return core.Date{Val: t}, nil
+	case core.Instant:
+		return core.DateOf(arg.Val), nil
+	case core.Date:
+		return arg, nil
+	}
+}
+
+var durationUnits = map[string]time.Duration{
+	"nanoseconds":  time.Nanosecond,
+	"microseconds": time.Microsecond,
+	"milliseconds": time.Millisecond,
+	"seconds":      time.Second,
+	"minutes":      time.Minute,
+	"hours":        time.Hour,
+}
+
+// (duration "1h30m") (duration seconds) or (duration n :minutes)
+func _duration(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 2, 3); err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	switch arg := val.(type) {
+	default:
+		return core.Null{}, fmt.Errorf("called with non-string or non-number %#v", val)
+	case core.String:
+		if err := exactLen(ast, 2); err != nil {
+			return core.Null{}, err
+		}
+		dur, err := time.ParseDuration(arg.Val)
+		return core.Duration{Val: dur}, err
+	case core.Number:
+		unit := time.Second
+		if len(ast) == 3 {
+			key, ok := core.Unwrap(ast[2]).(core.Keyword)
+			if unit, ok = durationUnits[key.Val]; !ok {
+				return core.Null{}, fmt.Errorf("unknown duration unit %#v", ast[2])
+			}
+		}
+		return newDuration(arg.Decimal(), unit), nil
+	}
+}
+
+// fractional count of unit, to the nanosecond
+func newDuration(num decimal.Decimal, unit time.Duration) core.Duration {
+	return core.Duration{Val: time.Duration(num.Mul(decimal.NewFromInt(int64(unit))).IntPart())}
+}
+
+// (period "P1Y2M3D") or (period years months days)
+func _period(ast core.Expr, env *base.Env) (core.Any, error) {
+	if len(ast) == 4 {
+		nums := make([]int, 3)
+		for i, item := range ast[1:] {
+			num, err := evalNumber(item, env)
+			if err != nil {
+				return core.Null{}, err
+			}
+			nums[i] = int(num.Decimal().IntPart())
+		}
+		return core.Period{Years: nums[0], Months: nums[1], Days: nums[2]}, nil
+	}
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	str, err := evalString(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.ParsePeriod(str.Val)
+}
+
+// (in-zone instant "Europe/London")
+func _inZone(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return core.Null{}, err
+	}
+	t, err := evalInstant(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	loc, err := evalLocation(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.Instant{Val: t.Val.In(loc)}, nil
+}
+
+func _zone(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := exactLen(ast, 2); err != nil {
+		return core.Null{}, err
+	}
+	t, err := evalInstant(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.String{Val: t.Val.Location().String()}, nil
+}
+
+// (format-time t) RFC 3339, or (format-time t "2006-01-02 15:04") Go layout
+func _formatTime(ast core.Expr, env *base.Env) (core.Any, error) {
+	if err := rangeLen(ast, 2, 3); err != nil {
+		return core.Null{}, err
+	}
+	val, err := base.Eval(ast[1], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	var t time.Time
+	switch arg := val.(type) {
+	default:
+		return core.Null{}, fmt.Errorf("called with non-instant %#v", val)
+	case core.Instant:
+		t = arg.Val
+	case core.Date:
+		t = arg.Val
+	}
+	if len(ast) == 2 {
+		return core.String{Val: val.String()}, nil
+	}
+	layout, err := evalString(ast[2], env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	return core.String{Val: t.Format(layout.Val)}, nil
+}
+
+// (truncate t :unit) start of second, minute, hour, day, month or year in t's zone
+func truncateTime(t time.Time, unit core.Any) (core.Instant, error) {
+	key, ok := core.Unwrap(unit).(core.Keyword)
+	if !ok {
+		return core.Instant{}, fmt.Errorf("called with non-keyword %#v", unit)
+	}
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	switch key.Val {
+	default:
+		return core.Instant{}, fmt.Errorf("unknown time unit %v", key)
+	case "year":
+		month = time.January
+		fallthrough
+	case "month":
+		day = 1
+		fallthrough
+	case "day":
+		hour = 0
+		fallthrough
+	case "hour":
+		min = 0
+		fallthrough
+	case "minute":
+		sec = 0
+		fallthrough
+	case "second":
+		break
+	}
+	return core.Instant{Val: time.Date(year, month, day, hour, min, sec, 0, t.Location())}, nil
+}
+
+// (add t dur-or-period...) (sub t dur-or-period...) (sub t1 t2)
+func addTime(args []core.Any, sub bool) (core.Any, error) {
+	res := args[0]
+	if sub && len(args) == 1 {
+		switch arg := res.(type) {
+		case core.Duration:
+			return core.Duration{Val: -arg.Val}, nil
+		case core.Period:
+			return arg.Neg(), nil
+		}
+		return core.Null{}, fmt.Errorf("cannot negate %#v", res)
+	}
+	for _, arg := range args[1:] {
+		var err error
+		if res, err = addTime2(res, arg, sub); err != nil {
+			return core.Null{}, err
+		}
+	}
+	return res, nil
+}
+
+func addTime2(a core.Any, b core.Any, sub bool) (core.Any, error) {
+	switch x := a.(type) {
+	case core.Instant:
+		switch y := b.(type) {
+		case core.Duration:
+			if sub {
+				return core.Instant{Val: x.Val.Add(-y.Val)}, nil
+			}
+			return core.Instant{Val: x.Val.Add(y.Val)}, nil
+		case core.Period:
+			if sub {
+				y = y.Neg()
+			}
+			return core.Instant{Val: y.AddTo(x.Val)}, nil
+		case core.Instant:
+			if sub {
+				return core.Duration{Val: x.Val.Sub(y.Val)}, nil
+			}
+		}
+	case core.Date:
+		switch y := b.(type) {
+		case core.Period:
+			if sub {
+				y = y.Neg()
+			}
+			return core.Date{Val: y.AddTo(x.Val)}, nil
+		case core.Date:
+			// whole days between dates
+			if sub {
+				return core.Period{Days: int(x.Val.Sub(y.Val).Hours() / 24)}, nil
+			}
+		}
+	case core.Duration:
+		if y, ok := b.(core.Duration); ok {
+			if sub {
+				return core.Duration{Val: x.Val - y.Val}, nil
+			}
+			return core.Duration{Val: x.Val + y.Val}, nil
+		}
+	case core.Period:
+		if y, ok := b.(core.Period); ok {
+			if sub {
+				y = y.Neg()
+			}
+			return core.Period{Years: x.Years + y.Years, Months: x.Months + y.Months, Days: x.Days + y.Days}, nil
+		}
+	}
+	if sub {
+		return core.Null{}, fmt.Errorf("cannot subtract %#v from %#v", b, a)
+	}
+	return core.Null{}, fmt.Errorf("cannot add %#v to %#v", b, a)
+}
+
+func hasTime(args []core.Any) bool {
+	for _, arg := range args {
+		switch arg.(type) {
+		case core.Instant, core.Date, core.Duration, core.Period:
+			return true
+		}
+	}
+	return false
+}
+
+func _instantQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	val, err := oneArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	_, ok := val.(core.Instant)
+	return core.Bool(ok), nil
+}
+
+func _dateQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	val, err := oneArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	_, ok := val.(core.Date)
+	return core.Bool(ok), nil
+}
+
+func _durationQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	val, err := oneArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	_, ok := val.(core.Duration)
+	return core.Bool(ok), nil
+}
+
+func _periodQ(ast core.Expr, env *base.Env) (core.Any, error) {
+	val, err := oneArg(ast, env)
+	if err != nil {
+		return core.Null{}, err
+	}
+	_, ok := val.(core.Period)
+	return core.Bool(ok), nil
+}
+
+func evalInstant(ast core.Any, env *base.Env) (core.Instant, error) {
+	val, err := base.Eval(ast, env)
+	if err != nil {
+		return core.Instant{}, err
+	}
+	t, ok := val.(core.Instant)
+	if !ok {
+		return core.Instant{}, fmt.Errorf("called with non-instant %#v", val)
+	}
+	return t, nil
+}
+
+func evalLocation(ast core.Any, env *base.Env) (*time.Location, error) {
+	name, err := evalString(ast, env)
+	if err != nil {
+		return nil, err
+	}
+	return time.LoadLocation(name.Val)
+}
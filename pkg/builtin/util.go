@@ -2,6 +2,8 @@ package builtin
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/starlight/ocelot/pkg/base"
 	"github.com/starlight/ocelot/pkg/core"
@@ -33,11 +35,19 @@ func evalNumber(ast core.Any, env *base.Env) (*core.Number, error) {
 	if err != nil {
 		return nil, err
 	}
+	num, err := numberArg(val)
+	if err != nil {
+		return nil, err
+	}
+	return &num, nil
+}
+
+func numberArg(val core.Any) (core.Number, error) {
 	switch num := val.(type) {
 	default:
-		return nil, fmt.Errorf("called with non-number %#v", val)
+		return core.Number{}, fmt.Errorf("called with non-number %#v", val)
 	case core.Number:
-		return &num, nil
+		return num, nil
 	}
 }
 
@@ -71,3 +81,270 @@ func cons(first core.Any, rest core.Expr) core.Expr {
 	}
 	return ast
 }
+
+// match (name arg) form, returning arg
+func isForm(ast core.Any, name string) (core.Any, bool) {
+	expr, ok := core.Unwrap(ast).(core.Expr)
+	if !ok || len(expr) != 2 {
+		return nil, false
+	}
+	sym, ok := expr[0].(core.Symbol)
+	if !ok || sym.Val != name {
+		return nil, false
+	}
+	return expr[1], true
+}
+
+// (quote val)
+func quote(val core.Any) core.Expr {
+	return core.Expr{core.NewSymbol("quote", nil), val}
+}
+
+// anything but false and null
+func truthy(val core.Any) bool {
+	return val != core.Bool(false) && val != core.Null{}
+}
+
+// is ast an s-expression with name at the head
+func isHead(ast core.Expr, name string) bool {
+	if len(ast) == 0 {
+		return false
+	}
+	sym, ok := ast[0].(core.Symbol)
+	return ok && sym.Val == name
+}
+
+func evalFunc(ast core.Any, env *base.Env) (base.Func, error) {
+	val, err := base.Eval(ast, env)
+	if err != nil {
+		return nil, err
+	}
+	switch fn := val.(type) {
+	default:
+		return nil, fmt.Errorf("called with non-function %#v", val)
+	case base.Func:
+		return fn, nil
+	}
+}
+
+func evalSeq(ast core.Any, env *base.Env) (core.Seq, error) {
+	val, err := base.Eval(ast, env)
+	if err != nil {
+		return nil, err
+	}
+	switch seq := val.(type) {
+	default:
+		return nil, fmt.Errorf("called with non-sequence %#v", val)
+	case core.Null:
+		return core.Vector{}, nil
+	case core.Seq:
+		return seq, nil
+	}
+}
+
+// lazy call of fn with already evaluated args, head is used for tracing
+func call(fn base.Func, head core.Any, env *base.Env, args ...core.Any) base.Future {
+	ast := make(core.Expr, len(args)+1)
+	ast[0] = head
+	for i, arg := range args {
+		ast[i+1] = quote(arg)
+	}
+	return fn.Future(ast, env)
+}
+
+func evalArgs(ast core.Expr, env *base.Env) ([]core.Any, error) {
+	res := make([]core.Any, len(ast))
+	for i, item := range ast {
+		val, err := base.Eval(item, env)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = val
+	}
+	return res, nil
+}
+
+func evalHash(ast core.Any, env *base.Env) (core.Hash, error) {
+	val, err := base.Eval(ast, env)
+	if err != nil {
+		return nil, err
+	}
+	switch hash := val.(type) {
+	default:
+		return nil, fmt.Errorf("called with non-hash %#v", val)
+	case core.Null:
+		return core.Hash{}, nil
+	case core.Hash:
+		return hash, nil
+	}
+}
+
+// seq after its first n items: a vector for vectors and exprs, as
+// destructured and matched rests always were, else still lazy
+func dropSeq(seq core.Seq, n int) (core.Any, error) {
+	switch arg := seq.(type) {
+	case core.Vector:
+		if n < len(arg) {
+			return arg[n:], nil
+		}
+		return core.Vector{}, nil
+	case core.Expr:
+		if n < len(arg) {
+			return core.Vector(arg[n:]), nil
+		}
+		return core.Vector{}, nil
+	}
+	for i := 0; i < n; i++ {
+		_, rest, ok, err := seq.Next()
+		if err != nil {
+			return core.Null{}, err
+		}
+		if !ok {
+			break
+		}
+		seq = rest
+	}
+	return seq, nil
+}
+
+// coll once its items are counted against the sandbox alloc quota
+func alloc(env *base.Env, coll core.Any) (core.Any, error) {
+	n := 0
+	switch arg := coll.(type) {
+	case core.Vector:
+		n = len(arg)
+	case core.Expr:
+		n = len(arg)
+	case core.Hash:
+		n = len(arg)
+	}
+	if err := env.Alloc(n); err != nil {
+		return core.Null{}, err
+	}
+	return coll, nil
+}
+
+// items of a finite seq, cancelled and limited with env
+func items(env *base.Env, seq core.Seq) ([]core.Any, error) {
+	return core.ItemsCheck(seq, func() error {
+		if err := env.Alloc(core.ItemsCheckEvery); err != nil {
+			return err
+		}
+		return env.Check()
+	})
+}
+
+// items of a finite seq
+func evalItems(ast core.Any, env *base.Env) ([]core.Any, error) {
+	seq, err := evalSeq(ast, env)
+	if err != nil {
+		return nil, err
+	}
+	return items(env, seq)
+}
+
+// order of comparable values
+func compare(a core.Any, b core.Any) (int, error) {
+	switch x := a.(type) {
+	case core.Number:
+		if y, ok := b.(core.Number); ok {
+			return x.Decimal().Cmp(y.Decimal()), nil
+		}
+	case core.String:
+		if y, ok := b.(core.String); ok {
+			return strings.Compare(x.Val, y.Val), nil
+		}
+	case core.Money:
+		if y, ok := b.(core.Money); ok {
+			return x.Cmp(y)
+		}
+	case core.Instant:
+		if y, ok := b.(core.Instant); ok {
+			return compareTime(x.Val, y.Val), nil
+		}
+	case core.Date:
+		if y, ok := b.(core.Date); ok {
+			return compareTime(x.Val, y.Val), nil
+		}
+	case core.Duration:
+		if y, ok := b.(core.Duration); ok {
+			return compareInt(int64(x.Val), int64(y.Val)), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot compare %#v with %#v", a, b)
+}
+
+func compareInt(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareTime(a time.Time, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// (op a b) by compare
+func evalCompare(ast core.Expr, env *base.Env) (int, error) {
+	if err := exactLen(ast, 3); err != nil {
+		return 0, err
+	}
+	val1, err := base.Eval(ast[1], env)
+	if err != nil {
+		return 0, err
+	}
+	val2, err := base.Eval(ast[2], env)
+	if err != nil {
+		return 0, err
+	}
+	return compare(val1, val2)
+}
+
+func evalString(ast core.Any, env *base.Env) (core.String, error) {
+	val, err := base.Eval(ast, env)
+	if err != nil {
+		return core.String{}, err
+	}
+	switch str := val.(type) {
+	default:
+		return core.String{}, fmt.Errorf("called with non-string %#v", val)
+	case core.String:
+		return str, nil
+	}
+}
+
+// seconds or duration
+func evalDuration(ast core.Any, env *base.Env) (time.Duration, error) {
+	val, err := base.Eval(ast, env)
+	if err != nil {
+		return 0, err
+	}
+	if dur, ok := val.(core.Duration); ok {
+		return dur.Val, nil
+	}
+	num, err := numberArg(val)
+	if err != nil {
+		return 0, err
+	}
+	return newDuration(num.Decimal(), time.Second).Val, nil
+}
+
+// a sandbox clock over plain cancellation
+func waitError(err error, env *base.Env) error {
+	if base.Cancelled(err) {
+		if check := env.Check(); check != nil {
+			return check
+		}
+	}
+	return err
+}
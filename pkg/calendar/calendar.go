@@ -0,0 +1,249 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/starlight/ocelot/pkg/core"
+)
+
+// type:calendar
+type Calendar struct {
+	Name     string
+	Location *time.Location
+	// session times as offsets from local midnight
+	Open  time.Duration
+	Close time.Duration
+	// trading weekdays
+	Weekdays [7]bool
+	// local dates, 2006-01-02
+	Holidays    map[string]bool
+	EarlyCloses map[string]time.Duration
+	LateOpens   map[string]time.Duration
+}
+
+func (cal *Calendar) String() string {
+	return "&calendar"
+}
+
+func (cal *Calendar) GoString() string {
+	return "&calendar<" + cal.Name + ">"
+}
+
+func (cal *Calendar) Equal(any core.Any) bool {
+	return cal == any
+}
+
+// file layout, TOML or JSON:
+//
+//	name = "XNYS"
+//	timezone = "America/New_York"
+//	open = "09:30"
+//	close = "16:00"
+//	weekdays = ["mon", "tue", "wed", "thu", "fri"]
+//	holidays = ["2024-01-01", "2024-12-25"]
+//	[early_closes]
+//	"2024-11-29" = "13:00"
+//	[late_opens]
+//	"2024-06-03" = "10:00"
+type spec struct {
+	Name        string
+	Timezone    string
+	Open        string
+	Close       string
+	Weekdays    []string
+	Holidays    []string
+	EarlyCloses map[string]string `mapstructure:"early_closes"`
+	LateOpens   map[string]string `mapstructure:"late_opens"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// calendar from a .toml or .json file
+func Load(path string) (*Calendar, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	var s spec
+	if err := v.Unmarshal(&s); err != nil {
+		return nil, err
+	}
+	cal, err := s.calendar()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cal, nil
+}
+
+func (s spec) calendar() (*Calendar, error) {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	cal := &Calendar{
+		Name:        s.Name,
+		Location:    loc,
+		Holidays:    make(map[string]bool),
+		EarlyCloses: make(map[string]time.Duration),
+		LateOpens:   make(map[string]time.Duration),
+	}
+	if cal.Open, err = parseClock(s.Open); err != nil {
+		return nil, err
+	}
+	if cal.Close, err = parseClock(s.Close); err != nil {
+		return nil, err
+	}
+	if cal.Close <= cal.Open {
+		return nil, fmt.Errorf("close %s is not after open %s", s.Close, s.Open)
+	}
+	if len(s.Weekdays) == 0 {
+		s.Weekdays = []string{"mon", "tue", "wed", "thu", "fri"}
+	}
+	for _, name := range s.Weekdays {
+		// mon, Monday
+		abbr := strings.ToLower(name)
+		if len(abbr) > 3 {
+			abbr = abbr[:3]
+		}
+		day, ok := weekdays[abbr]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+		cal.Weekdays[day] = true
+	}
+	for _, day := range s.Holidays {
+		if _, err := time.Parse(dateLayout, day); err != nil {
+			return nil, err
+		}
+		cal.Holidays[day] = true
+	}
+	if err := parseClocks(s.EarlyCloses, cal.EarlyCloses); err != nil {
+		return nil, err
+	}
+	if err := parseClocks(s.LateOpens, cal.LateOpens); err != nil {
+		return nil, err
+	}
+	return cal, nil
+}
+
+const dateLayout = "2006-01-02"
+
+// 15:04 as offset from midnight
+func parseClock(str string) (time.Duration, error) {
+	t, err := time.Parse("15:04", str)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q", str)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func parseClocks(clocks map[string]string, res map[string]time.Duration) error {
+	for day, clock := range clocks {
+		if _, err := time.Parse(dateLayout, day); err != nil {
+			return err
+		}
+		offset, err := parseClock(clock)
+		if err != nil {
+			return err
+		}
+		res[day] = offset
+	}
+	return nil
+}
+
+// local calendar date of t as midnight in the calendar zone
+func (cal *Calendar) day(t time.Time) time.Time {
+	year, month, day := t.In(cal.Location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, cal.Location)
+}
+
+// at local midnight, so DST days keep their wall clock times
+func (cal *Calendar) at(day time.Time, offset time.Duration) time.Time {
+	hours := int(offset / time.Hour)
+	minutes := int(offset % time.Hour / time.Minute)
+	return time.Date(day.Year(), day.Month(), day.Day(), hours, minutes, 0, 0, cal.Location)
+}
+
+// weekday in session and not a holiday
+func (cal *Calendar) IsTradingDay(t time.Time) bool {
+	day := cal.day(t)
+	return cal.Weekdays[day.Weekday()] && !cal.Holidays[day.Format(dateLayout)]
+}
+
+// open and close on the local date of t, ok is false when closed all day
+func (cal *Calendar) Session(t time.Time) (open time.Time, close time.Time, ok bool) {
+	if !cal.IsTradingDay(t) {
+		return time.Time{}, time.Time{}, false
+	}
+	day := cal.day(t)
+	key := day.Format(dateLayout)
+	openAt, closeAt := cal.Open, cal.Close
+	if offset, ok := cal.LateOpens[key]; ok {
+		openAt = offset
+	}
+	if offset, ok := cal.EarlyCloses[key]; ok {
+		closeAt = offset
+	}
+	return cal.at(day, openAt), cal.at(day, closeAt), true
+}
+
+// in session, open inclusive and close exclusive
+func (cal *Calendar) IsOpen(t time.Time) bool {
+	open, close, ok := cal.Session(t)
+	return ok && !t.Before(open) && t.Before(close)
+}
+
+// how far to look for a session before giving up
+const searchDays = 366 * 10
+
+// first open strictly after t
+func (cal *Calendar) NextOpen(t time.Time) (time.Time, error) {
+	day := cal.day(t)
+	for i := 0; i < searchDays; i++ {
+		if open, _, ok := cal.Session(day); ok && open.After(t) {
+			return open, nil
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}, fmt.Errorf("%s: no session after %v", cal.Name, t)
+}
+
+// last close at or before t
+func (cal *Calendar) PreviousClose(t time.Time) (time.Time, error) {
+	day := cal.day(t)
+	for i := 0; i < searchDays; i++ {
+		if _, close, ok := cal.Session(day); ok && !close.After(t) {
+			return close, nil
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return time.Time{}, fmt.Errorf("%s: no session before %v", cal.Name, t)
+}
+
+// trading days from the date of from up to, not including, the date of to
+func (cal *Calendar) TradingDaysBetween(from time.Time, to time.Time) int {
+	sign := 1
+	start, end := cal.day(from), cal.day(to)
+	if end.Before(start) {
+		start, end, sign = end, start, -1
+	}
+	count := 0
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if cal.IsTradingDay(day) {
+			count++
+		}
+	}
+	return sign * count
+}
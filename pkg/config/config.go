@@ -0,0 +1,299 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// value type of a key
+type Type int
+
+const (
+	String Type = iota
+	Bool
+	Int
+	Float
+	Duration
+)
+
+func (t Type) String() string {
+	switch t {
+	default:
+		return "string"
+	case Bool:
+		return "bool"
+	case Int:
+		return "int"
+	case Float:
+		return "float"
+	case Duration:
+		return "duration"
+	}
+}
+
+// a config key declared by the package that reads it
+type Key struct {
+	Name        string
+	Type        Type
+	Default     interface{}
+	Description string
+	// optional check of a typed value
+	Validate func(value interface{}) error
+}
+
+var registry = struct {
+	sync.Mutex
+	keys map[string]Key
+}{
+	keys: make(map[string]Key),
+}
+
+// declare a key, usually from package init
+func Register(key Key) {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.keys[key.Name]; ok {
+		panic(fmt.Sprintf("config key %q registered twice", key.Name))
+	}
+	registry.keys[key.Name] = key
+	viper.SetDefault(key.Name, key.Default)
+}
+
+func Lookup(name string) (Key, error) {
+	registry.Lock()
+	defer registry.Unlock()
+	key, ok := registry.keys[strings.ToLower(name)]
+	if !ok {
+		return Key{}, fmt.Errorf("unknown config key %q", name)
+	}
+	return key, nil
+}
+
+// registered keys by name
+func Keys() []Key {
+	registry.Lock()
+	defer registry.Unlock()
+	res := make([]Key, 0, len(registry.keys))
+	for _, key := range registry.keys {
+		res = append(res, key)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
+// selected profile, its [profile.<name>] settings override the top level
+var Profile string
+
+func profileKey(profile string, name string) string {
+	return "profile." + profile + "." + name
+}
+
+// where a set or unset goes in the config file
+func fileKey(name string) string {
+	if Profile != "" {
+		return profileKey(Profile, name)
+	}
+	return name
+}
+
+// effective typed value of key
+func Get(name string) (interface{}, error) {
+	key, err := Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	if Profile != "" && viper.IsSet(profileKey(Profile, key.Name)) {
+		return key.Cast(viper.Get(profileKey(Profile, key.Name)))
+	}
+	return key.Cast(viper.Get(key.Name))
+}
+
+func GetString(name string) string {
+	val, _ := Get(name)
+	return cast.ToString(val)
+}
+
+func GetBool(name string) bool {
+	val, _ := Get(name)
+	return cast.ToBool(val)
+}
+
+func GetInt(name string) int64 {
+	val, _ := Get(name)
+	return cast.ToInt64(val)
+}
+
+func GetDuration(name string) time.Duration {
+	val, _ := Get(name)
+	return cast.ToDuration(val)
+}
+
+// convert to the key type
+func (key Key) Cast(value interface{}) (interface{}, error) {
+	var res interface{}
+	var err error
+	switch key.Type {
+	default:
+		res, err = cast.ToStringE(value)
+	case Bool:
+		res, err = cast.ToBoolE(value)
+	case Int:
+		res, err = cast.ToInt64E(value)
+	case Float:
+		res, err = cast.ToFloat64E(value)
+	case Duration:
+		res, err = cast.ToDurationE(value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value %q for key %q", key.Type, cast.ToString(value), key.Name)
+	}
+	return res, nil
+}
+
+// typed and validated value
+func (key Key) Parse(value interface{}) (interface{}, error) {
+	res, err := key.Cast(value)
+	if err != nil {
+		return nil, err
+	}
+	if key.Validate != nil {
+		if err := key.Validate(res); err != nil {
+			return nil, fmt.Errorf("invalid value %q for key %q: %w", cast.ToString(value), key.Name, err)
+		}
+	}
+	return res, nil
+}
+
+// parse and write value to the config file, in the selected profile
+func Set(name string, value string) error {
+	key, err := Lookup(name)
+	if err != nil {
+		return err
+	}
+	res, err := key.Parse(value)
+	if err != nil {
+		return err
+	}
+	if dur, ok := res.(time.Duration); ok {
+		// as 1m30s rather than nanoseconds
+		res = dur.String()
+	}
+	return updateFile(func(settings map[string]interface{}) {
+		setPath(settings, strings.Split(fileKey(key.Name), "."), res)
+	})
+}
+
+// remove key from the config file, in the selected profile
+func Unset(name string) error {
+	key, err := Lookup(name)
+	if err != nil {
+		return err
+	}
+	return updateFile(func(settings map[string]interface{}) {
+		deletePath(settings, strings.Split(fileKey(key.Name), "."))
+	})
+}
+
+// check registered keys in the config file, at the top level and in every
+// profile, as written rather than cast to their defaults' types
+func Validate() error {
+	if viper.ConfigFileUsed() == "" {
+		return nil
+	}
+	file, err := readFile()
+	if err != nil {
+		return err
+	}
+	errs := []string{}
+	check := func(path string, key Key) {
+		if !file.IsSet(path) {
+			return
+		}
+		if _, err := key.Parse(file.Get(path)); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	for _, key := range Keys() {
+		check(key.Name, key)
+		for _, profile := range Profiles() {
+			check(profileKey(profile, key.Name), key)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// profile names in the config file
+func Profiles() []string {
+	res := []string{}
+	for name := range viper.GetStringMap("profile") {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// rewrite the config file, without defaults, and reload it
+func updateFile(fn func(settings map[string]interface{})) error {
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		return fmt.Errorf("no config file")
+	}
+	file, err := readFile()
+	if err != nil {
+		return err
+	}
+	settings := file.AllSettings()
+	fn(settings)
+	out := viper.New()
+	for key, val := range settings {
+		out.Set(key, val)
+	}
+	if err := out.WriteConfigAs(path); err != nil {
+		return err
+	}
+	return viper.ReadInConfig()
+}
+
+// the config file alone, without defaults or environment
+func readFile() (*viper.Viper, error) {
+	file := viper.New()
+	file.SetConfigFile(viper.ConfigFileUsed())
+	if err := file.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func setPath(settings map[string]interface{}, path []string, value interface{}) {
+	for _, name := range path[:len(path)-1] {
+		next, ok := settings[name].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			settings[name] = next
+		}
+		settings = next
+	}
+	settings[path[len(path)-1]] = value
+}
+
+func deletePath(settings map[string]interface{}, path []string) {
+	for _, name := range path[:len(path)-1] {
+		next, ok := settings[name].(map[string]interface{})
+		if !ok {
+			return
+		}
+		settings = next
+	}
+	delete(settings, path[len(path)-1])
+}
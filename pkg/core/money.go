@@ -0,0 +1,159 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/shopspring/decimal"
+)
+
+// type:money
+type Money struct {
+	Amount   decimal.Decimal
+	Currency string
+}
+
+// ISO 4217 minor units (digits after the point) by code
+var minorUnits = map[string]int32{
+	"AED": 2, "ARS": 2, "AUD": 2, "BGN": 2, "BHD": 3, "BRL": 2, "CAD": 2,
+	"CHF": 2, "CLP": 0, "CNY": 2, "COP": 2, "CZK": 2, "DKK": 2, "EGP": 2,
+	"EUR": 2, "GBP": 2, "HKD": 2, "HUF": 2, "IDR": 2, "ILS": 2, "INR": 2,
+	"IQD": 3, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0, "KWD": 3, "LYD": 3,
+	"MXN": 2, "MYR": 2, "NGN": 2, "NOK": 2, "NZD": 2, "OMR": 3, "PEN": 2,
+	"PHP": 2, "PKR": 2, "PLN": 2, "QAR": 2, "RON": 2, "RUB": 2, "SAR": 2,
+	"SEK": 2, "SGD": 2, "THB": 2, "TND": 3, "TRY": 2, "TWD": 2, "UAH": 2,
+	"UGX": 0, "USD": 2, "VND": 0, "XAF": 0, "XOF": 0, "ZAR": 2,
+}
+
+// digits after the point for currency code
+func MinorUnits(currency string) (int32, bool) {
+	units, ok := minorUnits[currency]
+	return units, ok
+}
+
+func NewMoney(amount decimal.Decimal, currency string) (Money, error) {
+	if _, ok := minorUnits[currency]; !ok {
+		return Money{}, fmt.Errorf("unknown currency %q", currency)
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// at least minor units digits
+func (val Money) String() string {
+	units := minorUnits[val.Currency]
+	if val.Amount.Exponent() >= -units {
+		return val.Amount.StringFixed(units) + " " + val.Currency
+	}
+	return val.Amount.String() + " " + val.Currency
+}
+
+func (val Money) GoString() string {
+	return val.String()
+}
+
+func (val Money) Equal(any Any) bool {
+	switch arg := any.(type) {
+	default:
+		return false
+	case Money:
+		return val.Currency == arg.Currency && val.Amount.Equal(arg.Amount)
+	}
+}
+
+// {"amount": "125.40", "currency": "USD"}, amount as string to keep it exact
+func (val Money) MarshalJSON() ([]byte, error) {
+	units := minorUnits[val.Currency]
+	amount := val.Amount.String()
+	if val.Amount.Exponent() >= -units {
+		amount = val.Amount.StringFixed(units)
+	}
+	return json.Marshal(map[string]string{
+		"amount":   amount,
+		"currency": val.Currency,
+	})
+}
+
+func (val *Money) UnmarshalJSON(data []byte) error {
+	var hash map[string]string
+	if err := json.Unmarshal(data, &hash); err != nil {
+		return err
+	}
+	amount, err := decimal.NewFromString(hash["amount"])
+	if err != nil {
+		return err
+	}
+	*val, err = NewMoney(amount, hash["currency"])
+	return err
+}
+
+func (val Money) same(arg Money) error {
+	if val.Currency != arg.Currency {
+		return fmt.Errorf("currency mismatch: %s and %s", val.Currency, arg.Currency)
+	}
+	return nil
+}
+
+func (val Money) Add(arg Money) (Money, error) {
+	if err := val.same(arg); err != nil {
+		return Money{}, err
+	}
+	return Money{val.Amount.Add(arg.Amount), val.Currency}, nil
+}
+
+func (val Money) Sub(arg Money) (Money, error) {
+	if err := val.same(arg); err != nil {
+		return Money{}, err
+	}
+	return Money{val.Amount.Sub(arg.Amount), val.Currency}, nil
+}
+
+func (val Money) Cmp(arg Money) (int, error) {
+	if err := val.same(arg); err != nil {
+		return 0, err
+	}
+	return val.Amount.Cmp(arg.Amount), nil
+}
+
+// split by ratios in minor units, remainder units go to the first parts
+func (val Money) Allocate(ratios []decimal.Decimal) ([]Money, error) {
+	total := decimal.Zero
+	for _, ratio := range ratios {
+		if ratio.IsNegative() {
+			return nil, fmt.Errorf("negative ratio %v", ratio)
+		}
+		total = total.Add(ratio)
+	}
+	if !total.IsPositive() {
+		return nil, fmt.Errorf("ratios sum to zero")
+	}
+	units := minorUnits[val.Currency]
+	minor := val.Amount.Shift(units)
+	if !minor.IsInteger() {
+		return nil, fmt.Errorf("%v is not in minor units, round it first", val)
+	}
+	whole := minor.Abs().BigInt()
+	left := new(big.Int).Set(whole)
+	parts := make([]*big.Int, len(ratios))
+	for i, ratio := range ratios {
+		part, _ := decimal.NewFromBigInt(whole, 0).Mul(ratio).QuoRem(total, 0)
+		parts[i] = part.BigInt()
+		left.Sub(left, parts[i])
+	}
+	for i := 0; left.Sign() > 0; i = (i + 1) % len(parts) {
+		if ratios[i].IsZero() {
+			continue
+		}
+		parts[i].Add(parts[i], big.NewInt(1))
+		left.Sub(left, big.NewInt(1))
+	}
+	res := make([]Money, len(parts))
+	for i, part := range parts {
+		amount := decimal.NewFromBigInt(part, -units)
+		if val.Amount.IsNegative() {
+			amount = amount.Neg()
+		}
+		res[i] = Money{amount, val.Currency}
+	}
+	return res, nil
+}
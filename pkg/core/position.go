@@ -0,0 +1,127 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// named source text
+type Source struct {
+	Name string
+	Text []byte
+}
+
+// span of source text: Line and Col at Offset, up to End
+type Position struct {
+	Line, Col, Offset int
+	End               int
+	Source            *Source
+}
+
+// file.oc:12:5
+func (pos *Position) String() string {
+	if pos.Source == nil {
+		return fmt.Sprintf("%d:%d", pos.Line, pos.Col)
+	}
+	return fmt.Sprintf("%s:%d:%d", pos.Source.Name, pos.Line, pos.Col)
+}
+
+// source line with carets under the span
+func (pos *Position) Excerpt() string {
+	if pos.Source == nil || pos.Offset > len(pos.Source.Text) {
+		return ""
+	}
+	text := pos.Source.Text
+	start := bytes.LastIndexByte(text[:pos.Offset], '\n') + 1
+	end := bytes.IndexByte(text[pos.Offset:], '\n')
+	if end < 0 {
+		end = len(text)
+	} else {
+		end += pos.Offset
+	}
+	line := string(text[start:end])
+	// keep tabs so carets line up
+	indent := strings.Map(func(r rune) rune {
+		if r == '\t' {
+			return r
+		}
+		return ' '
+	}, string(text[start:pos.Offset]))
+	width := 1
+	if pos.End > pos.Offset && pos.End <= end {
+		width = utf8.RuneCount(text[pos.Offset:pos.End])
+	}
+	return "  " + line + "\n  " + indent + "^" + strings.Repeat("~", width-1)
+}
+
+// parsed node at its source position, as produced by the parser for all
+// but symbols, which carry their own
+type Node struct {
+	Val Any
+	Pos *Position
+}
+
+func (node Node) String() string {
+	return node.Val.String()
+}
+
+func (node Node) GoString() string {
+	return node.Val.GoString()
+}
+
+func (node Node) Equal(any Any) bool {
+	return node.Val.Equal(Unwrap(any))
+}
+
+// value of a parsed node, else ast itself
+func Unwrap(ast Any) Any {
+	if node, ok := ast.(Node); ok {
+		return node.Val
+	}
+	return ast
+}
+
+// copy of parsed ast without its nodes, for use as data; nodes hold only
+// symbols and other nodes, so any other value is returned as is
+func Strip(ast Any) Any {
+	node, ok := ast.(Node)
+	if !ok {
+		return ast
+	}
+	switch any := node.Val.(type) {
+	default:
+		return any
+	case Expr:
+		return Expr(stripSeq(any))
+	case Vector:
+		return Vector(stripSeq(any))
+	case Hash:
+		res := make(Hash, len(any))
+		for key, val := range any {
+			res[key] = Strip(val)
+		}
+		return res
+	}
+}
+
+func stripSeq(seq []Any) []Any {
+	res := make([]Any, len(seq))
+	for i, item := range seq {
+		res[i] = Strip(item)
+	}
+	return res
+}
+
+// source position of ast node, or nil
+func PosOf(ast Any) *Position {
+	switch any := ast.(type) {
+	default:
+		return nil
+	case Symbol:
+		return any.Pos
+	case Node:
+		return any.Pos
+	}
+}
@@ -0,0 +1,245 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// interface:seq
+type Seq interface {
+	Any
+	// first item and the rest, ok is false when empty
+	Next() (first Any, rest Seq, ok bool, err error)
+}
+
+func (val Vector) Next() (Any, Seq, bool, error) {
+	if len(val) == 0 {
+		return Null{}, Vector{}, false, nil
+	}
+	return val[0], val[1:], true, nil
+}
+
+func (val Expr) Next() (Any, Seq, bool, error) {
+	if len(val) == 0 {
+		return Null{}, Expr{}, false, nil
+	}
+	return val[0], val[1:], true, nil
+}
+
+// [key value] pairs ordered by key
+func (val Hash) Next() (Any, Seq, bool, error) {
+	return val.Pairs().Next()
+}
+
+func (val Hash) Pairs() Vector {
+	keys := make([]String, 0, len(val))
+	for key := range val {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Val < keys[j].Val
+	})
+	res := make(Vector, len(keys))
+	for i, key := range keys {
+		res[i] = Vector{key, val[key]}
+	}
+	return res
+}
+
+// characters (runes) as strings
+func (val String) Next() (Any, Seq, bool, error) {
+	if val.Val == "" {
+		return Null{}, val, false, nil
+	}
+	_, size := utf8.DecodeRuneInString(val.Val)
+	return String{val.Val[:size]}, String{val.Val[size:]}, true, nil
+}
+
+// type:cons
+type Cons struct {
+	First Any
+	Rest  Seq
+}
+
+func (val Cons) Next() (Any, Seq, bool, error) {
+	return val.First, val.Rest, true, nil
+}
+
+func (val Cons) String() string {
+	return seqString(val, "%v")
+}
+
+func (val Cons) GoString() string {
+	return seqString(val, "%#v")
+}
+
+func (val Cons) Equal(any Any) bool {
+	return seqEqual(val, any)
+}
+
+// type:lazy-seq
+type LazySeq struct {
+	*lazySeq
+}
+
+type lazySeq struct {
+	once sync.Once
+	fn   func() (Seq, error)
+	seq  Seq
+	err  error
+}
+
+// seq realized by fn on first use
+func NewLazySeq(fn func() (Seq, error)) LazySeq {
+	return LazySeq{&lazySeq{fn: fn}}
+}
+
+func (val LazySeq) Realize() (Seq, error) {
+	val.once.Do(func() {
+		val.seq, val.err = val.fn()
+		val.fn = nil
+	})
+	return val.seq, val.err
+}
+
+func (val LazySeq) Next() (Any, Seq, bool, error) {
+	seq, err := val.Realize()
+	if err != nil {
+		return Null{}, nil, false, err
+	}
+	return seq.Next()
+}
+
+func (val LazySeq) String() string {
+	return seqString(val, "%v")
+}
+
+func (val LazySeq) GoString() string {
+	return seqString(val, "%#v")
+}
+
+func (val LazySeq) Equal(any Any) bool {
+	return seqEqual(val, any)
+}
+
+// items of a finite seq
+func Items(seq Seq) ([]Any, error) {
+	return ItemsCheck(seq, func() error {
+		return nil
+	})
+}
+
+// how many items are realized between checks
+const ItemsCheckEvery = 1024
+
+// items of a seq, giving up once check fails
+func ItemsCheck(seq Seq, check func() error) ([]Any, error) {
+	switch any := seq.(type) {
+	case Vector:
+		return any, nil
+	case Expr:
+		return any, nil
+	case Hash:
+		return any.Pairs(), nil
+	}
+	res := []Any{}
+	for {
+		if len(res)%ItemsCheckEvery == ItemsCheckEvery-1 {
+			if err := check(); err != nil {
+				return res, err
+			}
+		}
+		first, rest, ok, err := seq.Next()
+		if err != nil || !ok {
+			return res, err
+		}
+		res = append(res, first)
+		seq = rest
+	}
+}
+
+// print lazy seqs up to a limit, they may be infinite
+const seqPrintLimit = 32
+
+func seqString(seq Seq, format string) string {
+	res := []string{}
+	for i := 0; ; i++ {
+		if i == seqPrintLimit {
+			res = append(res, "...")
+			break
+		}
+		first, rest, ok, err := seq.Next()
+		if err != nil {
+			res = append(res, "<error>")
+			break
+		}
+		if !ok {
+			break
+		}
+		res = append(res, fmt.Sprintf(format, first))
+		seq = rest
+	}
+	return "(" + strings.Join(res, " ") + ")"
+}
+
+// first error realizing the part of val that printing shows, check
+// before printing since String and GoString cannot return it
+func PrintError(val Any) error {
+	var items []Any
+	switch arg := val.(type) {
+	default:
+		return nil
+	case Vector:
+		items = arg
+	case Expr:
+		items = arg
+	case Hash:
+		for _, item := range arg {
+			items = append(items, item)
+		}
+	case Cons, LazySeq:
+		seq := arg.(Seq)
+		for i := 0; i < seqPrintLimit; i++ {
+			first, rest, ok, err := seq.Next()
+			if err != nil || !ok {
+				return err
+			}
+			items = append(items, first)
+			seq = rest
+		}
+	}
+	for _, item := range items {
+		if err := PrintError(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lazy seqs are equal to lazy seqs, vectors and exprs with equal items
+func seqEqual(seq Seq, any Any) bool {
+	switch any.(type) {
+	default:
+		return false
+	case Cons, LazySeq, Vector, Expr:
+		break
+	}
+	other := any.(Seq)
+	for {
+		a, restA, okA, errA := seq.Next()
+		b, restB, okB, errB := other.Next()
+		if errA != nil || errB != nil || okA != okB {
+			return false
+		}
+		if !okA {
+			return true
+		}
+		if !a.Equal(b) {
+			return false
+		}
+		seq, other = restA, restB
+	}
+}
@@ -0,0 +1,166 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// type:instant
+type Instant struct {
+	Val time.Time
+}
+
+func (val Instant) String() string {
+	return val.Val.Format(time.RFC3339Nano)
+}
+
+func (val Instant) GoString() string {
+	return val.String()
+}
+
+func (val Instant) Equal(any Any) bool {
+	switch arg := any.(type) {
+	default:
+		return false
+	case Instant:
+		return val.Val.Equal(arg.Val)
+	}
+}
+
+// type:date
+type Date struct {
+	Val time.Time
+}
+
+// calendar date, held as midnight UTC
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// date of instant in its own zone
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return NewDate(year, month, day)
+}
+
+func (val Date) String() string {
+	return val.Val.Format("2006-01-02")
+}
+
+func (val Date) GoString() string {
+	return val.String()
+}
+
+func (val Date) Equal(any Any) bool {
+	switch arg := any.(type) {
+	default:
+		return false
+	case Date:
+		return val.Val.Equal(arg.Val)
+	}
+}
+
+// type:duration
+type Duration struct {
+	Val time.Duration
+}
+
+func (val Duration) String() string {
+	return val.Val.String()
+}
+
+func (val Duration) GoString() string {
+	return val.String()
+}
+
+func (val Duration) Equal(any Any) bool {
+	switch arg := any.(type) {
+	default:
+		return false
+	case Duration:
+		return val == arg
+	}
+}
+
+// type:period
+type Period struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// ISO 8601 form, P1Y2M3D
+func (val Period) String() string {
+	if val == (Period{}) {
+		return "P0D"
+	}
+	var sb strings.Builder
+	sb.WriteString("P")
+	if val.Years != 0 {
+		fmt.Fprintf(&sb, "%dY", val.Years)
+	}
+	if val.Months != 0 {
+		fmt.Fprintf(&sb, "%dM", val.Months)
+	}
+	if val.Days != 0 {
+		fmt.Fprintf(&sb, "%dD", val.Days)
+	}
+	return sb.String()
+}
+
+func (val Period) GoString() string {
+	return val.String()
+}
+
+func (val Period) Equal(any Any) bool {
+	switch arg := any.(type) {
+	default:
+		return false
+	case Period:
+		return val == arg
+	}
+}
+
+func (val Period) Neg() Period {
+	return Period{-val.Years, -val.Months, -val.Days}
+}
+
+// add months clamped to the end of month (Jan 31 + P1M is Feb 29), then days
+func (val Period) AddTo(t time.Time) time.Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	months := int(month) - 1 + val.Years*12 + val.Months
+	year, month = year+months/12, time.Month(months%12+1)
+	if months%12 < 0 {
+		year, month = year-1, month+12
+	}
+	if last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day(); day > last {
+		day = last
+	}
+	return time.Date(year, month, day+val.Days, hour, min, sec, t.Nanosecond(), t.Location())
+}
+
+var periodPattern = regexp.MustCompile(`^P(?:(-?\d+)Y)?(?:(-?\d+)M)?(?:(-?\d+)W)?(?:(-?\d+)D)?$`)
+
+// parse P1Y2M3D, weeks are counted as 7 days
+func ParsePeriod(str string) (Period, error) {
+	match := periodPattern.FindStringSubmatch(str)
+	if match == nil || str == "P" {
+		return Period{}, fmt.Errorf("invalid period %q", str)
+	}
+	nums := make([]int, 4)
+	for i, part := range match[1:] {
+		if part == "" {
+			continue
+		}
+		num, err := strconv.Atoi(part)
+		if err != nil {
+			return Period{}, err
+		}
+		nums[i] = num
+	}
+	return Period{Years: nums[0], Months: nums[1], Days: nums[2]*7 + nums[3]}, nil
+}
@@ -102,19 +102,13 @@ type Symbol struct {
 	Pos *Position
 }
 
-type Position struct {
-	Line, Col, Offset int
-}
-
 func (val Symbol) String() string {
 	return fmt.Sprintf("%s", val.Val)
 }
 
+// position is left to errors, as file:line:col
 func (val Symbol) GoString() string {
-	if val.Pos != nil {
-		return fmt.Sprintf("%s<%d,%d;%d>", val.Val, val.Pos.Line, val.Pos.Col, val.Pos.Offset)
-	}
-	return fmt.Sprintf("%s<?>", val.Val)
+	return val.String()
 }
 
 func (val Symbol) Equal(any Any) bool {
@@ -126,6 +120,28 @@ func (val Symbol) Equal(any Any) bool {
 	}
 }
 
+// type:keyword
+type Keyword struct {
+	Val string
+}
+
+func (val Keyword) String() string {
+	return ":" + val.Val
+}
+
+func (val Keyword) GoString() string {
+	return val.String()
+}
+
+func (val Keyword) Equal(any Any) bool {
+	switch arg := any.(type) {
+	default:
+		return false
+	case Keyword:
+		return val == arg
+	}
+}
+
 // type:expr
 type Expr []Any
 
@@ -159,6 +175,8 @@ func (val Expr) Equal(any Any) bool {
 	switch arg := any.(type) {
 	default:
 		return false
+	case Cons, LazySeq:
+		return seqEqual(arg.(Seq), val)
 	case Expr:
 		if len(val) != len(arg) {
 			return false
@@ -206,6 +224,8 @@ func (val Vector) Equal(any Any) bool {
 	switch arg := any.(type) {
 	default:
 		return false
+	case Cons, LazySeq:
+		return seqEqual(arg.(Seq), val)
 	case Vector:
 		if len(val) != len(arg) {
 			return false
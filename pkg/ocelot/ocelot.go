@@ -1,8 +1,10 @@
 package ocelot
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"sync"
 
 	goprompt "github.com/c-bata/go-prompt"
 	"github.com/fatih/color"
@@ -12,22 +14,38 @@ import (
 	"golang.org/x/term"
 )
 
-func Repl(prompt string) error {
-	env, err := builtin.BuiltinEnv()
+// read-eval-print until EOF, or until ctx is done which calls quit
+func Repl(ctx context.Context, prompt string, quit func()) error {
+	builtins, err := builtin.BuiltinEnv()
 	if err != nil {
 		return err
 	}
+	env := base.NewEnvContext(ctx, builtins)
+	// held while evaluating
+	var running sync.Mutex
 	executor := func(in string) {
 		if in == "" {
 			return
 		}
+		running.Lock()
+		defer running.Unlock()
 		val, err := base.EvalStr(in, env)
+		if err == nil {
+			err = core.PrintError(val)
+		}
 		if err != nil {
 			fmt.Println(err)
 			return
 		}
 		Print(val)
 	}
+	go func() {
+		<-ctx.Done()
+		// let a cancelled evaluation unwind first
+		running.Lock()
+		restoreTermState()
+		quit()
+	}()
 	completer := func(d goprompt.Document) []goprompt.Suggest {
 		return []goprompt.Suggest{}
 	}